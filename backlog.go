@@ -52,3 +52,8 @@ func selectDomain(domain string) string {
 		return DomainJp
 	}
 }
+
+// Bool returns a pointer to v, for optional filters such as CountNotificationQuery.AlreadyRead.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,291 @@
+package burndown
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ksmt88/go-backlog"
+)
+
+type Option struct {
+	ClosedStatuses backlog.ClosedStatuses // default: backlog.DefaultClosedStatuses
+	Location       *time.Location         // day boundaries, default: time.Local
+	Now            time.Time              // days after Now are not computed, default: time.Now()
+}
+
+type Day struct {
+	Date            time.Time `json:"date"`
+	RemainingHours  float64   `json:"remainingHours"`  // estimated hours of open issues at the end of the day
+	RemainingIssues int       `json:"remainingIssues"` // open issues at the end of the day
+	TotalHours      float64   `json:"totalHours"`      // estimated hours of all issues in scope
+	TotalIssues     int       `json:"totalIssues"`
+	IdealHours      float64   `json:"idealHours"`
+	IdealIssues     float64   `json:"idealIssues"`
+}
+
+const (
+	ScopeAdded    = "added"
+	ScopeRemoved  = "removed"
+	ScopeEstimate = "estimate"
+)
+
+type ScopeChange struct {
+	Date     time.Time `json:"date"`
+	IssueKey string    `json:"issueKey"`
+	Type     string    `json:"type"`  // ScopeAdded, ScopeRemoved or ScopeEstimate
+	Hours    float64   `json:"hours"` // change of the estimated hours in scope
+}
+
+type Chart struct {
+	Milestone    backlog.Version `json:"milestone"`
+	Start        time.Time       `json:"start"`
+	End          time.Time       `json:"end"`
+	Days         []Day           `json:"days"` // one per day from Start until End or Now
+	ScopeChanges []ScopeChange   `json:"scopeChanges"`
+}
+
+// Get fetches the milestone's issues with their history and computes the chart. Issues that were
+// removed from the milestone are no longer returned by the API, so their removal is not shown.
+func Get(s *backlog.Service, projectIdOrKey string, milestoneId int, option Option) (Chart, error) {
+	statuses, err := s.GetStatusList(projectIdOrKey)
+	if err != nil {
+		return Chart{}, err
+	}
+	option.ClosedStatuses = option.ClosedStatuses.Resolve(statuses...)
+
+	versions, err := s.GetVersionList(projectIdOrKey)
+	if err != nil {
+		return Chart{}, err
+	}
+
+	var milestone *backlog.Version
+	for i := range versions {
+		if versions[i].ID == milestoneId {
+			milestone = &versions[i]
+		}
+	}
+	if milestone == nil {
+		return Chart{}, errors.New("milestone not found: " + strconv.Itoa(milestoneId))
+	}
+
+	histories, err := getHistories(s, backlog.GetIssueListQuery{ProjectId: []int{milestone.ProjectID}, MilestoneId: []int{milestoneId}})
+	if err != nil {
+		return Chart{}, err
+	}
+
+	return New(*milestone, histories, option)
+}
+
+// New computes the chart of milestone from the history of its issues. Closed statuses are only known by
+// name from the issues' current status, so resolve option.ClosedStatuses with GetStatusList when issues
+// may have been reopened.
+func New(milestone backlog.Version, histories []backlog.IssueHistory, option Option) (Chart, error) {
+	option = option.withDefaults()
+	for _, history := range histories {
+		option.ClosedStatuses = option.ClosedStatuses.Resolve(backlog.Status(history.Issue.Status))
+	}
+
+	start, err := parseDate(milestone.StartDate, option.Location)
+	if err != nil || start.IsZero() {
+		return Chart{}, errors.New("milestone has no start date: " + milestone.Name)
+	}
+	end, err := parseDate(milestone.ReleaseDueDate, option.Location)
+	if err != nil {
+		return Chart{}, err
+	}
+	if end.IsZero() || end.Before(start) {
+		end = startOfDay(option.Now, option.Location)
+	}
+
+	chart := Chart{
+		Milestone: milestone,
+		Start:     start,
+		End:       end,
+	}
+
+	// the scope at the end of the first day is the baseline of the ideal line, changes after it are scope changes
+	var previous []issueState
+	var baseline Day
+
+	days := int(end.Sub(start).Hours()/24+0.5) + 1
+	for d := 0; d < days; d++ {
+		date := start.AddDate(0, 0, d)
+		if date.After(option.Now) {
+			break
+		}
+		cutoff := date.AddDate(0, 0, 1)
+		if cutoff.After(option.Now) {
+			cutoff = option.Now
+		}
+
+		states := make([]issueState, len(histories))
+		for i, history := range histories {
+			states[i] = stateAt(history, milestone.Name, cutoff, option)
+			if previous != nil {
+				chart.ScopeChanges = append(chart.ScopeChanges, scopeChanges(date, history.Issue.IssueKey, previous[i], states[i])...)
+			}
+		}
+		previous = states
+
+		day := sum(states)
+		day.Date = date
+		if d == 0 {
+			baseline = day
+		}
+		if days > 1 {
+			ratio := 1 - float64(d)/float64(days-1)
+			day.IdealHours = baseline.TotalHours * ratio
+			day.IdealIssues = float64(baseline.TotalIssues) * ratio
+		}
+		chart.Days = append(chart.Days, day)
+	}
+
+	return chart, nil
+}
+
+type issueState struct {
+	inScope bool
+	closed  bool
+	hours   float64
+}
+
+func stateAt(history backlog.IssueHistory, milestone string, t time.Time, option Option) issueState {
+	issue := history.Issue
+	if issue.Created.After(t) {
+		return issueState{}
+	}
+
+	state := issueState{}
+
+	if value, ok := history.ValueAt(backlog.IssueFieldMilestone, t); ok {
+		state.inScope = containsName(value, milestone)
+	} else {
+		for _, m := range issue.Milestone {
+			if m.Name == milestone {
+				state.inScope = true
+			}
+		}
+	}
+
+	if value, ok := history.ValueAt(backlog.IssueFieldStatus, t); ok {
+		state.closed = option.ClosedStatuses.IsClosed(0, value)
+	} else {
+		state.closed = option.ClosedStatuses.IsClosed(issue.Status.ID, issue.Status.Name)
+	}
+
+	if value, ok := history.ValueAt(backlog.IssueFieldEstimatedHours, t); ok {
+		state.hours = Hours(value)
+	} else {
+		state.hours = Hours(issue.EstimatedHours)
+	}
+
+	return state
+}
+
+func sum(states []issueState) Day {
+	var day Day
+	for _, state := range states {
+		if !state.inScope {
+			continue
+		}
+		day.TotalHours += state.hours
+		day.TotalIssues++
+		if !state.closed {
+			day.RemainingHours += state.hours
+			day.RemainingIssues++
+		}
+	}
+	return day
+}
+
+func scopeChanges(date time.Time, issueKey string, before issueState, after issueState) []ScopeChange {
+	switch {
+	case !before.inScope && after.inScope:
+		return []ScopeChange{{Date: date, IssueKey: issueKey, Type: ScopeAdded, Hours: after.hours}}
+	case before.inScope && !after.inScope:
+		return []ScopeChange{{Date: date, IssueKey: issueKey, Type: ScopeRemoved, Hours: -before.hours}}
+	case before.inScope && before.hours != after.hours:
+		return []ScopeChange{{Date: date, IssueKey: issueKey, Type: ScopeEstimate, Hours: after.hours - before.hours}}
+	default:
+		return nil
+	}
+}
+
+// Hours reads EstimatedHours and ActualHours, which the API returns as a number or null,
+// as well as change log values. Anything else is 0.
+func Hours(v interface{}) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f
+	default:
+		return 0
+	}
+}
+
+func getHistories(s *backlog.Service, query backlog.GetIssueListQuery) ([]backlog.IssueHistory, error) {
+	issues, err := s.GetAllIssues(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var histories []backlog.IssueHistory
+	for _, issue := range issues {
+		comments, err := s.GetAllComments(issue.IssueKey)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, backlog.NewIssueHistory(issue, comments))
+	}
+	return histories, nil
+}
+
+// containsName matches a milestone change log value, which lists multiple milestones separated by commas.
+func containsName(value string, name string) bool {
+	if value == name {
+		return true
+	}
+	for _, v := range strings.Split(value, ",") {
+		if strings.TrimSpace(v) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDate reads the calendar date of a version date such as 2019-11-13T00:00:00Z in loc.
+func parseDate(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if len(s) >= 10 {
+		s = s[:10]
+	}
+	return time.ParseInLocation("2006-01-02", s, loc)
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func (o Option) withDefaults() Option {
+	o.ClosedStatuses = o.ClosedStatuses.Resolve()
+	if o.Location == nil {
+		o.Location = time.Local
+	}
+	if o.Now.IsZero() {
+		o.Now = time.Now()
+	}
+	return o
+}
@@ -0,0 +1,61 @@
+package burndown
+
+import (
+	"strconv"
+
+	"github.com/ksmt88/go-backlog"
+)
+
+type Velocity struct {
+	Milestone               backlog.Version `json:"milestone"`
+	Issues                  int             `json:"issues"`
+	EstimatedHours          float64         `json:"estimatedHours"`
+	CompletedIssues         int             `json:"completedIssues"`
+	CompletedEstimatedHours float64         `json:"completedEstimatedHours"`
+	CompletedActualHours    float64         `json:"completedActualHours"`
+}
+
+// GetVelocity returns the velocity of every milestone of the project, in display order.
+// Archived milestones are included so that past velocity can be compared.
+func GetVelocity(s *backlog.Service, projectIdOrKey string, option Option) ([]Velocity, error) {
+	versions, err := s.GetVersionList(projectIdOrKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var velocities []Velocity
+	for _, version := range versions {
+		issues, err := s.GetAllIssues(backlog.GetIssueListQuery{ProjectId: []int{version.ProjectID}, MilestoneId: []int{version.ID}})
+		if err != nil {
+			return nil, err
+		}
+		velocities = append(velocities, NewVelocity(version, issues, option))
+	}
+
+	return velocities, nil
+}
+
+// NewVelocity sums the hours of the milestone's issues as they are now.
+func NewVelocity(milestone backlog.Version, issues []backlog.Issue, option Option) Velocity {
+	option = option.withDefaults()
+
+	velocity := Velocity{Milestone: milestone}
+	for _, issue := range issues {
+		estimated := Hours(issue.EstimatedHours)
+		velocity.Issues++
+		velocity.EstimatedHours += estimated
+
+		if option.ClosedStatuses.IsClosed(issue.Status.ID, issue.Status.Name) {
+			velocity.CompletedIssues++
+			velocity.CompletedEstimatedHours += estimated
+			velocity.CompletedActualHours += Hours(issue.ActualHours)
+		}
+	}
+
+	return velocity
+}
+
+func (v Velocity) String() string {
+	return v.Milestone.Name + ": " + strconv.Itoa(v.CompletedIssues) + "/" + strconv.Itoa(v.Issues) + " issues, " +
+		strconv.FormatFloat(v.CompletedEstimatedHours, 'f', -1, 64) + "/" + strconv.FormatFloat(v.EstimatedHours, 'f', -1, 64) + "h"
+}
@@ -0,0 +1,241 @@
+package backlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	DiskUsageTotal      = "total"
+	DiskUsageIssue      = "issue"
+	DiskUsageWiki       = "wiki"
+	DiskUsageFile       = "file"
+	DiskUsageSubversion = "subversion"
+	DiskUsageGit        = "git"
+	DiskUsageGitLFS     = "gitLFS"
+)
+
+var diskUsageCategories = []string{DiskUsageIssue, DiskUsageWiki, DiskUsageFile, DiskUsageSubversion, DiskUsageGit, DiskUsageGitLFS}
+
+type DiskUsageThreshold struct {
+	Category string  `json:"category"` // DiskUsageTotal (default) or one of the categories
+	Percent  float64 `json:"percent"`  // of the space capacity
+	Level    string  `json:"level"`    // e.g. warning, critical
+}
+
+type DiskUsageAlert struct {
+	Category  string  `json:"category"`
+	Level     string  `json:"level"`
+	Usage     int64   `json:"usage"`
+	Capacity  int64   `json:"capacity"`
+	Percent   float64 `json:"percent"`
+	Threshold float64 `json:"threshold"`
+}
+
+type ProjectDiskUsageRank struct {
+	ProjectID  int     `json:"projectId"`
+	ProjectKey string  `json:"projectKey,omitempty"`
+	Name       string  `json:"name,omitempty"`
+	Usage      int64   `json:"usage"`
+	Percent    float64 `json:"percent"`
+}
+
+type DiskUsageCategory struct {
+	Category string                 `json:"category"`
+	Usage    int64                  `json:"usage"`
+	Percent  float64                `json:"percent"`
+	Projects []ProjectDiskUsageRank `json:"projects"` // largest first
+}
+
+type DiskUsageReport struct {
+	Capacity   int64               `json:"capacity"`
+	Usage      int64               `json:"usage"`
+	Percent    float64             `json:"percent"`
+	Categories []DiskUsageCategory `json:"categories"`
+	Alerts     []DiskUsageAlert    `json:"alerts"`
+}
+
+func (s *Service) GetDiskUsageReport(thresholds []DiskUsageThreshold) (DiskUsageReport, error) {
+	diskUsage, err := s.GetSpaceDiskUsage()
+	if err != nil {
+		return DiskUsageReport{}, err
+	}
+
+	projects, err := s.GetProjectList()
+	if err != nil {
+		return DiskUsageReport{}, err
+	}
+
+	return NewDiskUsageReport(diskUsage, projects, thresholds), nil
+}
+
+// NewDiskUsageReport ranks projects per category and evaluates thresholds. projects is only used for names and may be nil.
+func NewDiskUsageReport(diskUsage DiskUsage, projects []Project, thresholds []DiskUsageThreshold) DiskUsageReport {
+	report := DiskUsageReport{
+		Capacity: diskUsage.Capacity,
+		Usage:    diskUsage.Issue + diskUsage.Wiki + diskUsage.File + diskUsage.Subversion + diskUsage.Git + diskUsage.GitLFS,
+	}
+	report.Percent = percentOf(report.Usage, report.Capacity)
+
+	projectById := map[int]Project{}
+	for _, project := range projects {
+		projectById[project.ID] = project
+	}
+
+	for _, category := range diskUsageCategories {
+		usage := diskUsageOf(category, diskUsage.Issue, diskUsage.Wiki, diskUsage.File, diskUsage.Subversion, diskUsage.Git, diskUsage.GitLFS)
+		diskUsageCategory := DiskUsageCategory{
+			Category: category,
+			Usage:    usage,
+			Percent:  percentOf(usage, report.Capacity),
+		}
+
+		for _, detail := range diskUsage.Details {
+			rank := ProjectDiskUsageRank{
+				ProjectID: detail.ProjectID,
+				Usage:     diskUsageOf(category, detail.Issue, detail.Wiki, detail.File, detail.Subversion, detail.Git, detail.GitLFS),
+			}
+			rank.Percent = percentOf(rank.Usage, report.Capacity)
+			if project, ok := projectById[detail.ProjectID]; ok {
+				rank.ProjectKey = project.ProjectKey
+				rank.Name = project.Name
+			}
+			diskUsageCategory.Projects = append(diskUsageCategory.Projects, rank)
+		}
+		sort.SliceStable(diskUsageCategory.Projects, func(i, j int) bool {
+			return diskUsageCategory.Projects[i].Usage > diskUsageCategory.Projects[j].Usage
+		})
+
+		report.Categories = append(report.Categories, diskUsageCategory)
+	}
+
+	report.Alerts = report.evaluate(thresholds)
+
+	return report
+}
+
+// evaluate returns at most one alert per category, for the highest threshold that is reached.
+func (r DiskUsageReport) evaluate(thresholds []DiskUsageThreshold) []DiskUsageAlert {
+	var alerts []DiskUsageAlert
+	alertByCategory := map[string]int{}
+
+	for _, threshold := range thresholds {
+		if threshold.Category == "" {
+			threshold.Category = DiskUsageTotal
+		}
+		usage, percent, ok := r.usageOf(threshold.Category)
+		if !ok || percent < threshold.Percent {
+			continue
+		}
+
+		alert := DiskUsageAlert{
+			Category:  threshold.Category,
+			Level:     threshold.Level,
+			Usage:     usage,
+			Capacity:  r.Capacity,
+			Percent:   percent,
+			Threshold: threshold.Percent,
+		}
+		if i, ok := alertByCategory[threshold.Category]; ok {
+			if alerts[i].Threshold < threshold.Percent {
+				alerts[i] = alert
+			}
+			continue
+		}
+		alertByCategory[threshold.Category] = len(alerts)
+		alerts = append(alerts, alert)
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].Percent > alerts[j].Percent
+	})
+
+	return alerts
+}
+
+func (r DiskUsageReport) usageOf(category string) (int64, float64, bool) {
+	if category == DiskUsageTotal {
+		return r.Usage, r.Percent, true
+	}
+	for _, diskUsageCategory := range r.Categories {
+		if diskUsageCategory.Category == category {
+			return diskUsageCategory.Usage, diskUsageCategory.Percent, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (r DiskUsageReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Text renders the report for plain-text output such as cron mail. top limits the projects listed per category.
+func (r DiskUsageReport) Text(top int) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Disk usage: %s / %s (%.1f%%)\n", formatBytes(r.Usage), formatBytes(r.Capacity), r.Percent)
+
+	if len(r.Alerts) > 0 {
+		b.WriteString("\nAlerts:\n")
+		for _, alert := range r.Alerts {
+			fmt.Fprintf(&b, "  [%s] %s: %s (%.1f%%, threshold %.1f%%)\n", alert.Level, alert.Category, formatBytes(alert.Usage), alert.Percent, alert.Threshold)
+		}
+	}
+
+	for _, category := range r.Categories {
+		fmt.Fprintf(&b, "\n%s: %s (%.1f%%)\n", category.Category, formatBytes(category.Usage), category.Percent)
+		for i, project := range category.Projects {
+			if top > 0 && i >= top || project.Usage == 0 {
+				break
+			}
+			name := project.ProjectKey
+			if name == "" {
+				name = fmt.Sprintf("#%d", project.ProjectID)
+			}
+			fmt.Fprintf(&b, "  %2d. %-20s %10s (%.1f%%)\n", i+1, name, formatBytes(project.Usage), project.Percent)
+		}
+	}
+
+	return b.String()
+}
+
+func diskUsageOf(category string, issue, wiki, file, subversion, git, gitLFS int64) int64 {
+	switch category {
+	case DiskUsageIssue:
+		return issue
+	case DiskUsageWiki:
+		return wiki
+	case DiskUsageFile:
+		return file
+	case DiskUsageSubversion:
+		return subversion
+	case DiskUsageGit:
+		return git
+	case DiskUsageGitLFS:
+		return gitLFS
+	default:
+		return 0
+	}
+}
+
+func percentOf(usage int64, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return float64(usage) * 100 / float64(capacity)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,504 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+type Repository struct {
+	ID           int        `json:"id"`
+	ProjectID    int        `json:"projectId"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	HookURL      string     `json:"hookUrl"`
+	HTTPURL      string     `json:"httpUrl"`
+	SSHURL       string     `json:"sshUrl"`
+	DisplayOrder int        `json:"displayOrder"`
+	PushedAt     *time.Time `json:"pushedAt"`
+	CreatedUser  User       `json:"createdUser"`
+	Created      time.Time  `json:"created"`
+	UpdatedUser  User       `json:"updatedUser"`
+	Updated      time.Time  `json:"updated"`
+}
+
+const (
+	PullRequestStatusOpen   = 1
+	PullRequestStatusClosed = 2
+	PullRequestStatusMerged = 3
+)
+
+type PullRequestStatus struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type PullRequest struct {
+	ID           int               `json:"id"`
+	ProjectID    int               `json:"projectId"`
+	RepositoryID int               `json:"repositoryId"`
+	Number       int               `json:"number"`
+	Summary      string            `json:"summary"`
+	Description  string            `json:"description"`
+	Base         string            `json:"base"`
+	Branch       string            `json:"branch"`
+	Status       PullRequestStatus `json:"status"`
+	Assignee     *User             `json:"assignee"`
+	Issue        *Issue            `json:"issue"`
+	BaseCommit   *string           `json:"baseCommit"`
+	BranchCommit *string           `json:"branchCommit"`
+	MergeCommit  *string           `json:"mergeCommit"`
+	CloseAt      *time.Time        `json:"closeAt"`
+	MergeAt      *time.Time        `json:"mergeAt"`
+	CreatedUser  User              `json:"createdUser"`
+	Created      time.Time         `json:"created"`
+	UpdatedUser  User              `json:"updatedUser"`
+	Updated      time.Time         `json:"updated"`
+	Attachments  []Attachment      `json:"attachments"`
+	Stars        []Star            `json:"stars"`
+}
+
+type PullRequestComment struct {
+	ID            int           `json:"id"`
+	Content       string        `json:"content"`
+	ChangeLog     []ChangeLog   `json:"changeLog"`
+	CreatedUser   User          `json:"createdUser"`
+	Created       time.Time     `json:"created"`
+	Updated       time.Time     `json:"updated"`
+	Stars         []Star        `json:"stars"`
+	Notifications []interface{} `json:"notifications"`
+}
+
+type GetPullRequestListQuery struct {
+	StatusId      []int
+	AssigneeId    []int
+	IssueId       []int
+	CreatedUserId []int
+	Offset        int
+	Count         int // default: 20, max: 100
+}
+
+type PullRequestParams struct {
+	Summary        string
+	Description    string
+	Base           string // add only
+	Branch         string // add only
+	IssueId        int
+	AssigneeId     int
+	NotifiedUserId []int
+	AttachmentId   []int  // add only
+	Comment        string // update only
+}
+
+type GetPullRequestCommentListQuery struct {
+	MinId int
+	MaxId int
+	Count int    // default: 20, max: 100
+	Order string // asc, desc (default)
+}
+
+func (s *Service) GetRepositoryList(projectIdOrKey string) ([]Repository, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []Repository
+	err = json.Unmarshal(body, &repositories)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return repositories, nil
+}
+
+func (s *Service) GetRepository(projectIdOrKey string, repoIdOrName string) (Repository, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var repository Repository
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return repository, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return repository, err
+	}
+
+	err = json.Unmarshal(body, &repository)
+	if err != nil {
+		return repository, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return repository, nil
+}
+
+func (s *Service) GetPullRequestList(projectIdOrKey string, repoIdOrName string, query GetPullRequestListQuery) ([]PullRequest, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests"
+	urlParams := pullRequestListParams(query)
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequests []PullRequest
+	err = json.Unmarshal(body, &pullRequests)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return pullRequests, nil
+}
+
+func (s *Service) CountPullRequest(projectIdOrKey string, repoIdOrName string, query GetPullRequestListQuery) (int, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/count"
+	urlParams := pullRequestListParams(query)
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var count struct {
+		Count int
+	}
+	err = json.Unmarshal(body, &count)
+	if err != nil {
+		return 0, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return count.Count, nil
+}
+
+func pullRequestListParams(query GetPullRequestListQuery) url.Values {
+	urlParams := url.Values{}
+	for _, statusId := range query.StatusId {
+		urlParams.Add("statusId[]", strconv.Itoa(statusId))
+	}
+	for _, assigneeId := range query.AssigneeId {
+		urlParams.Add("assigneeId[]", strconv.Itoa(assigneeId))
+	}
+	for _, issueId := range query.IssueId {
+		urlParams.Add("issueId[]", strconv.Itoa(issueId))
+	}
+	for _, createdUserId := range query.CreatedUserId {
+		urlParams.Add("createdUserId[]", strconv.Itoa(createdUserId))
+	}
+	if query.Offset != 0 {
+		urlParams.Add("offset", strconv.Itoa(query.Offset))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	return urlParams
+}
+
+func (s *Service) GetPullRequest(projectIdOrKey string, repoIdOrName string, number int) (PullRequest, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var pullRequest PullRequest
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return pullRequest, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return pullRequest, err
+	}
+
+	err = json.Unmarshal(body, &pullRequest)
+	if err != nil {
+		return pullRequest, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return pullRequest, nil
+}
+
+func (s *Service) AddPullRequest(projectIdOrKey string, repoIdOrName string, params PullRequestParams) (PullRequest, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("summary", params.Summary)
+	requestParams.Add("description", params.Description)
+	requestParams.Add("base", params.Base)
+	requestParams.Add("branch", params.Branch)
+	if params.IssueId != 0 {
+		requestParams.Add("issueId", strconv.Itoa(params.IssueId))
+	}
+	if params.AssigneeId != 0 {
+		requestParams.Add("assigneeId", strconv.Itoa(params.AssigneeId))
+	}
+	for _, notifiedUserId := range params.NotifiedUserId {
+		requestParams.Add("notifiedUserId[]", strconv.Itoa(notifiedUserId))
+	}
+	for _, attachmentId := range params.AttachmentId {
+		requestParams.Add("attachmentId[]", strconv.Itoa(attachmentId))
+	}
+
+	var pullRequest PullRequest
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return pullRequest, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return pullRequest, err
+	}
+
+	err = json.Unmarshal(body, &pullRequest)
+	if err != nil {
+		return pullRequest, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return pullRequest, nil
+}
+
+func (s *Service) UpdatePullRequest(projectIdOrKey string, repoIdOrName string, number int, params PullRequestParams) (PullRequest, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	if params.Summary != "" {
+		requestParams.Add("summary", params.Summary)
+	}
+	if params.Description != "" {
+		requestParams.Add("description", params.Description)
+	}
+	if params.IssueId != 0 {
+		requestParams.Add("issueId", strconv.Itoa(params.IssueId))
+	}
+	if params.AssigneeId != 0 {
+		requestParams.Add("assigneeId", strconv.Itoa(params.AssigneeId))
+	}
+	for _, notifiedUserId := range params.NotifiedUserId {
+		requestParams.Add("notifiedUserId[]", strconv.Itoa(notifiedUserId))
+	}
+	if params.Comment != "" {
+		requestParams.Add("comment", params.Comment)
+	}
+
+	var pullRequest PullRequest
+
+	req, err := http.NewRequest(http.MethodPatch, requestUrl+"?"+urlParams.Encode(), strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return pullRequest, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return pullRequest, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return pullRequest, err
+	}
+
+	err = json.Unmarshal(body, &pullRequest)
+	if err != nil {
+		return pullRequest, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return pullRequest, nil
+}
+
+func (s *Service) GetPullRequestCommentList(projectIdOrKey string, repoIdOrName string, number int, query GetPullRequestCommentListQuery) ([]PullRequestComment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number) + "/comments"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.MinId != 0 {
+		urlParams.Add("minId", strconv.Itoa(query.MinId))
+	}
+	if query.MaxId != 0 {
+		urlParams.Add("maxId", strconv.Itoa(query.MaxId))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []PullRequestComment
+	err = json.Unmarshal(body, &comments)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return comments, nil
+}
+
+func (s *Service) AddPullRequestComment(projectIdOrKey string, repoIdOrName string, number int, content string, notifiedUserIds []int) (PullRequestComment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number) + "/comments"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("content", content)
+	for _, notifiedUserId := range notifiedUserIds {
+		requestParams.Add("notifiedUserId[]", strconv.Itoa(notifiedUserId))
+	}
+
+	var comment PullRequestComment
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return comment, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return comment, err
+	}
+
+	err = json.Unmarshal(body, &comment)
+	if err != nil {
+		return comment, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return comment, nil
+}
+
+func (s *Service) UpdatePullRequestComment(projectIdOrKey string, repoIdOrName string, number int, commentId int, content string) (PullRequestComment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number) + "/comments/" + strconv.Itoa(commentId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("content", content)
+
+	var comment PullRequestComment
+
+	req, err := http.NewRequest(http.MethodPatch, requestUrl+"?"+urlParams.Encode(), strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return comment, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return comment, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return comment, err
+	}
+
+	err = json.Unmarshal(body, &comment)
+	if err != nil {
+		return comment, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return comment, nil
+}
+
+func (s *Service) GetPullRequestAttachmentList(projectIdOrKey string, repoIdOrName string, number int) ([]Attachment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number) + "/attachments"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []Attachment
+	err = json.Unmarshal(body, &attachments)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return attachments, nil
+}
+
+func (s *Service) GetPullRequestAttachment(projectIdOrKey string, repoIdOrName string, number int, attachmentId int) (io.ReadCloser, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/git/repositories/" + repoIdOrName + "/pullRequests/" + strconv.Itoa(number) + "/attachments/" + strconv.Itoa(attachmentId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return res.Body, nil
+}
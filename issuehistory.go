@@ -0,0 +1,133 @@
+package backlog
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	IssueFieldStatus         = "status"
+	IssueFieldAssigner       = "assigner"
+	IssueFieldMilestone      = "milestone"
+	IssueFieldVersion        = "version"
+	IssueFieldCategory       = "component"
+	IssueFieldIssueType      = "issueType"
+	IssueFieldPriority       = "priority"
+	IssueFieldResolution     = "resolution"
+	IssueFieldSummary        = "summary"
+	IssueFieldDescription    = "description"
+	IssueFieldStartDate      = "startDate"
+	IssueFieldDueDate        = "limitDate"
+	IssueFieldEstimatedHours = "estimatedHours"
+	IssueFieldActualHours    = "actualHours"
+	IssueFieldParentIssue    = "parentIssue"
+	IssueFieldAttachment     = "attachment"
+)
+
+type IssueFieldChange struct {
+	Field         string    `json:"field"`
+	OriginalValue string    `json:"originalValue"`
+	NewValue      string    `json:"newValue"`
+	CommentID     int       `json:"commentId"`
+	ChangedUser   User      `json:"changedUser"`
+	Changed       time.Time `json:"changed"`
+}
+
+// IssueHistory holds the changes of an issue, oldest first.
+type IssueHistory struct {
+	Issue    Issue              `json:"issue"`
+	Comments []Comment          `json:"comments"`
+	Changes  []IssueFieldChange `json:"changes"`
+}
+
+func (s *Service) GetIssueHistory(issueIdOrKey string) (IssueHistory, error) {
+	issue, err := s.GetIssue(issueIdOrKey)
+	if err != nil {
+		return IssueHistory{}, err
+	}
+
+	comments, err := s.GetAllComments(issueIdOrKey)
+	if err != nil {
+		return IssueHistory{}, err
+	}
+
+	return NewIssueHistory(issue, comments), nil
+}
+
+// GetAllComments pages through GetCommentList and returns every comment, oldest first.
+func (s *Service) GetAllComments(issueIdOrKey string) ([]Comment, error) {
+	var all []Comment
+	minId := 0
+	for {
+		comments, err := s.GetCommentList(issueIdOrKey, GetCommentListQuery{MinId: minId, Count: 100, Order: "asc"})
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, comment := range comments {
+			if comment.ID <= minId {
+				continue
+			}
+			all = append(all, comment)
+			minId = comment.ID
+			added++
+		}
+		if len(comments) < 100 || added == 0 {
+			return all, nil
+		}
+	}
+}
+
+func NewIssueHistory(issue Issue, comments []Comment) IssueHistory {
+	history := IssueHistory{
+		Issue:    issue,
+		Comments: comments,
+	}
+
+	for _, comment := range comments {
+		for _, changeLog := range comment.ChangeLog {
+			history.Changes = append(history.Changes, IssueFieldChange{
+				Field:         changeLog.Field,
+				OriginalValue: changeLog.OriginalValue,
+				NewValue:      changeLog.NewValue,
+				CommentID:     comment.ID,
+				ChangedUser:   comment.CreatedUser,
+				Changed:       comment.Created,
+			})
+		}
+	}
+	sort.SliceStable(history.Changes, func(i, j int) bool {
+		return history.Changes[i].Changed.Before(history.Changes[j].Changed)
+	})
+
+	return history
+}
+
+func (h IssueHistory) FieldChanges(field string) []IssueFieldChange {
+	var changes []IssueFieldChange
+	for _, change := range h.Changes {
+		if change.Field == field {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// ValueAt returns the value of field at t. ok is false when the field never changed,
+// in which case the current value of the issue applies.
+func (h IssueHistory) ValueAt(field string, t time.Time) (value string, ok bool) {
+	changes := h.FieldChanges(field)
+	if len(changes) == 0 {
+		return "", false
+	}
+
+	value = changes[0].OriginalValue
+	for _, change := range changes {
+		if change.Changed.After(t) {
+			break
+		}
+		value = change.NewValue
+	}
+	return value, true
+}
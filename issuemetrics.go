@@ -0,0 +1,355 @@
+package backlog
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatusIdClosed is the ID of Closed in Backlog's default statuses. Its name can be changed per space.
+const StatusIdClosed = 4
+
+// ClosedStatuses decides which statuses close an issue. The current status is matched by ID, but change
+// logs only carry status names, so past statuses are matched by Names, which Resolve fills in.
+type ClosedStatuses struct {
+	Ids   []int
+	Names []string
+}
+
+var DefaultClosedStatuses = ClosedStatuses{Ids: []int{StatusIdClosed}}
+
+// Resolve adds the names of the statuses whose ID is closed. An empty ClosedStatuses resolves DefaultClosedStatuses.
+func (c ClosedStatuses) Resolve(statuses ...Status) ClosedStatuses {
+	if len(c.Ids) == 0 && len(c.Names) == 0 {
+		c = DefaultClosedStatuses
+	}
+
+	resolved := ClosedStatuses{Ids: c.Ids, Names: append([]string(nil), c.Names...)}
+	for _, status := range statuses {
+		if resolved.IsClosed(status.ID, "") && !resolved.IsClosed(0, status.Name) {
+			resolved.Names = append(resolved.Names, status.Name)
+		}
+	}
+	return resolved
+}
+
+// IsClosed matches statusId against Ids and name against Names. Pass 0 when only the name is known.
+func (c ClosedStatuses) IsClosed(statusId int, name string) bool {
+	for _, id := range c.Ids {
+		if statusId != 0 && statusId == id {
+			return true
+		}
+	}
+	for _, closed := range c.Names {
+		if name != "" && name == closed {
+			return true
+		}
+	}
+	return false
+}
+
+type IssueMetricsOption struct {
+	ClosedStatuses ClosedStatuses // statuses that end the cycle, default: DefaultClosedStatuses
+	Now            time.Time      // end of the current status period, default: time.Now()
+}
+
+type StatusPeriod struct {
+	Status   string        `json:"status"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"duration"`
+}
+
+type IssueMetrics struct {
+	IssueKey       string                   `json:"issueKey"`
+	Summary        string                   `json:"summary"`
+	Status         string                   `json:"status"`
+	Created        time.Time                `json:"created"`
+	Started        time.Time                `json:"started"` // first status change, zero when never started
+	Closed         time.Time                `json:"closed"`  // zero unless the issue is closed
+	FirstResponded time.Time                `json:"firstResponded"`
+	LeadTime       time.Duration            `json:"leadTime"`      // Created to Closed
+	CycleTime      time.Duration            `json:"cycleTime"`     // Started to Closed
+	FirstResponse  time.Duration            `json:"firstResponse"` // Created to the first reply by someone other than the reporter
+	Reopens        int                      `json:"reopens"`
+	Timeline       []StatusPeriod           `json:"timeline"`
+	TimeInStatus   map[string]time.Duration `json:"timeInStatus"`
+}
+
+type DurationPercentiles struct {
+	Count int           `json:"count"`
+	P50   time.Duration `json:"p50"`
+	P75   time.Duration `json:"p75"`
+	P90   time.Duration `json:"p90"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+type IssueMetricsSummary struct {
+	Issues        int                 `json:"issues"`
+	Closed        int                 `json:"closed"`
+	Reopens       int                 `json:"reopens"`
+	LeadTime      DurationPercentiles `json:"leadTime"`
+	CycleTime     DurationPercentiles `json:"cycleTime"`
+	FirstResponse DurationPercentiles `json:"firstResponse"`
+}
+
+// GetIssueMetrics fetches the history of every issue matching query. It makes one request per issue,
+// so narrow the query on large projects. The names of the closed statuses are read from each project.
+func (s *Service) GetIssueMetrics(query GetIssueListQuery, option IssueMetricsOption) ([]IssueMetrics, IssueMetricsSummary, error) {
+	issues, err := s.GetAllIssues(query)
+	if err != nil {
+		return nil, IssueMetricsSummary{}, err
+	}
+
+	closedStatuses := map[int]ClosedStatuses{}
+	var metrics []IssueMetrics
+	for _, issue := range issues {
+		closed, ok := closedStatuses[issue.ProjectID]
+		if !ok {
+			statuses, err := s.GetStatusList(strconv.Itoa(issue.ProjectID))
+			if err != nil {
+				return nil, IssueMetricsSummary{}, err
+			}
+			closed = option.ClosedStatuses.Resolve(statuses...)
+			closedStatuses[issue.ProjectID] = closed
+		}
+
+		comments, err := s.GetAllComments(issue.IssueKey)
+		if err != nil {
+			return nil, IssueMetricsSummary{}, err
+		}
+		metrics = append(metrics, NewIssueMetrics(NewIssueHistory(issue, comments), IssueMetricsOption{ClosedStatuses: closed, Now: option.Now}))
+	}
+
+	return metrics, SummarizeIssueMetrics(metrics), nil
+}
+
+// NewIssueMetrics only knows the name of the issue's current status. Resolve option.ClosedStatuses with
+// GetStatusList to recognise closures in the history of issues that have been reopened since.
+func NewIssueMetrics(history IssueHistory, option IssueMetricsOption) IssueMetrics {
+	issue := history.Issue
+	closed := option.ClosedStatuses.Resolve(Status(issue.Status))
+	if option.Now.IsZero() {
+		option.Now = time.Now()
+	}
+	isClosed := func(status string) bool {
+		return closed.IsClosed(0, status)
+	}
+
+	metrics := IssueMetrics{
+		IssueKey:     issue.IssueKey,
+		Summary:      issue.Summary,
+		Status:       issue.Status.Name,
+		Created:      issue.Created,
+		TimeInStatus: map[string]time.Duration{},
+	}
+
+	changes := history.FieldChanges(IssueFieldStatus)
+	period := StatusPeriod{Status: issue.Status.Name, Start: issue.Created}
+	if len(changes) > 0 {
+		period.Status = changes[0].OriginalValue
+		metrics.Started = changes[0].Changed
+	}
+	for _, change := range changes {
+		period.End = change.Changed
+		metrics.Timeline = append(metrics.Timeline, period)
+
+		if isClosed(change.NewValue) {
+			metrics.Closed = change.Changed
+		} else if isClosed(change.OriginalValue) {
+			metrics.Reopens++
+		}
+		period = StatusPeriod{Status: change.NewValue, Start: change.Changed}
+	}
+	period.End = option.Now
+	if period.End.Before(period.Start) {
+		period.End = period.Start
+	}
+	metrics.Timeline = append(metrics.Timeline, period)
+
+	for i := range metrics.Timeline {
+		metrics.Timeline[i].Duration = metrics.Timeline[i].End.Sub(metrics.Timeline[i].Start)
+		metrics.TimeInStatus[metrics.Timeline[i].Status] += metrics.Timeline[i].Duration
+	}
+
+	if closed.IsClosed(issue.Status.ID, issue.Status.Name) {
+		if metrics.Closed.IsZero() {
+			metrics.Closed = issue.Created
+		}
+		metrics.LeadTime = metrics.Closed.Sub(issue.Created)
+		if !metrics.Started.IsZero() {
+			metrics.CycleTime = metrics.Closed.Sub(metrics.Started)
+		}
+	} else {
+		metrics.Closed = time.Time{}
+	}
+
+	for _, comment := range history.Comments {
+		if comment.CreatedUser.ID != issue.CreatedUser.ID && strings.TrimSpace(comment.Content) != "" {
+			metrics.FirstResponded = comment.Created
+			metrics.FirstResponse = comment.Created.Sub(issue.Created)
+			break
+		}
+	}
+
+	return metrics
+}
+
+// SummarizeIssueMetrics aggregates closed issues for lead and cycle time and responded issues for first response.
+func SummarizeIssueMetrics(metrics []IssueMetrics) IssueMetricsSummary {
+	summary := IssueMetricsSummary{Issues: len(metrics)}
+
+	var leadTimes, cycleTimes, firstResponses []time.Duration
+	for _, m := range metrics {
+		summary.Reopens += m.Reopens
+		if !m.Closed.IsZero() {
+			summary.Closed++
+			leadTimes = append(leadTimes, m.LeadTime)
+			if !m.Started.IsZero() {
+				cycleTimes = append(cycleTimes, m.CycleTime)
+			}
+		}
+		if !m.FirstResponded.IsZero() {
+			firstResponses = append(firstResponses, m.FirstResponse)
+		}
+	}
+
+	summary.LeadTime = NewDurationPercentiles(leadTimes)
+	summary.CycleTime = NewDurationPercentiles(cycleTimes)
+	summary.FirstResponse = NewDurationPercentiles(firstResponses)
+
+	return summary
+}
+
+// NewDurationPercentiles uses the nearest-rank method.
+func NewDurationPercentiles(durations []time.Duration) DurationPercentiles {
+	percentiles := DurationPercentiles{Count: len(durations)}
+	if len(durations) == 0 {
+		return percentiles
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := func(p float64) time.Duration {
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i]
+	}
+
+	percentiles.P50 = rank(50)
+	percentiles.P75 = rank(75)
+	percentiles.P90 = rank(90)
+	percentiles.P95 = rank(95)
+	percentiles.Max = sorted[len(sorted)-1]
+
+	return percentiles
+}
+
+// WriteIssueMetricsCSV writes one row per issue. Durations are in hours and the time spent in each
+// status follows the fixed columns, in order of first appearance.
+func WriteIssueMetricsCSV(w io.Writer, metrics []IssueMetrics) error {
+	var statuses []string
+	seen := map[string]bool{}
+	for _, m := range metrics {
+		for _, period := range m.Timeline {
+			if !seen[period.Status] {
+				seen[period.Status] = true
+				statuses = append(statuses, period.Status)
+			}
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{"issueKey", "summary", "status", "created", "started", "closed", "leadTimeHours", "cycleTimeHours", "firstResponseHours", "reopens"}
+	for _, status := range statuses {
+		header = append(header, status)
+	}
+	err := writer.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range metrics {
+		record := []string{
+			m.IssueKey,
+			m.Summary,
+			m.Status,
+			formatCSVTime(m.Created),
+			formatCSVTime(m.Started),
+			formatCSVTime(m.Closed),
+			formatCSVHours(m.LeadTime, !m.Closed.IsZero()),
+			formatCSVHours(m.CycleTime, !m.Closed.IsZero() && !m.Started.IsZero()),
+			formatCSVHours(m.FirstResponse, !m.FirstResponded.IsZero()),
+			strconv.Itoa(m.Reopens),
+		}
+		for _, status := range statuses {
+			duration, ok := m.TimeInStatus[status]
+			record = append(record, formatCSVHours(duration, ok))
+		}
+		err = writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func WriteIssueMetricsSummaryCSV(w io.Writer, summary IssueMetricsSummary) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"metric", "count", "p50Hours", "p75Hours", "p90Hours", "p95Hours", "maxHours"})
+	if err != nil {
+		return err
+	}
+
+	rows := []struct {
+		name        string
+		percentiles DurationPercentiles
+	}{
+		{"leadTime", summary.LeadTime},
+		{"cycleTime", summary.CycleTime},
+		{"firstResponse", summary.FirstResponse},
+	}
+	for _, row := range rows {
+		ok := row.percentiles.Count > 0
+		err = writer.Write([]string{
+			row.name,
+			strconv.Itoa(row.percentiles.Count),
+			formatCSVHours(row.percentiles.P50, ok),
+			formatCSVHours(row.percentiles.P75, ok),
+			formatCSVHours(row.percentiles.P90, ok),
+			formatCSVHours(row.percentiles.P95, ok),
+			formatCSVHours(row.percentiles.Max, ok),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatCSVHours(d time.Duration, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+}
@@ -21,9 +21,9 @@ type GetIssueListQuery struct {
 	AssigneeId     []int
 	CreatedUserId  []int
 	ResolutionId   []int
-	ParentChild    int // 0: All, 1: Exclude Child Issue, 2: Child Issue, 3: Neither Parent Issue nor Child Issue, 4: Parent Issue
-	Attachment     bool
-	SharedFile     bool
+	ParentChild    int    // 0: All, 1: Exclude Child Issue, 2: Child Issue, 3: Neither Parent Issue nor Child Issue, 4: Parent Issue
+	Attachment     *bool  // nil: not filtered
+	SharedFile     *bool  // nil: not filtered
 	Sort           string // issueType, category, version, milestone, summary, status, priority, attachment, sharedFile, created, createdUser, updated, updatedUser, assignee, startDate, dueDate, estimatedHours, actualHours, childIssue, customField_${id}
 	Order          string
 	Offset         int
@@ -96,21 +96,48 @@ type Issue struct {
 		Size int    `json:"size"`
 	} `json:"attachments"`
 	SharedFiles []SharedFile `json:"sharedFiles"`
-	Stars       []struct {
-		ID        int         `json:"id"`
-		Comment   interface{} `json:"comment"`
-		URL       string      `json:"url"`
-		Title     string      `json:"title"`
-		Presenter struct {
-			ID          int    `json:"id"`
-			UserID      string `json:"userId"`
-			Name        string `json:"name"`
-			RoleType    int    `json:"roleType"`
-			Lang        string `json:"lang"`
-			MailAddress string `json:"mailAddress"`
-		} `json:"presenter"`
-		Created time.Time `json:"created"`
-	} `json:"stars"`
+	Stars       []Star       `json:"stars"`
+}
+
+type GetCommentListQuery struct {
+	MinId int
+	MaxId int
+	Count int    // default: 20, max: 100
+	Order string // asc, desc (default)
+}
+
+type Comment struct {
+	ID            int           `json:"id"`
+	Content       string        `json:"content"`
+	ChangeLog     []ChangeLog   `json:"changeLog"`
+	CreatedUser   User          `json:"createdUser"`
+	Created       time.Time     `json:"created"`
+	Updated       time.Time     `json:"updated"`
+	Stars         []Star        `json:"stars"`
+	Notifications []interface{} `json:"notifications"`
+}
+
+type ChangeLog struct {
+	Field            string                     `json:"field"`
+	NewValue         string                     `json:"newValue"`
+	OriginalValue    string                     `json:"originalValue"`
+	AttachmentInfo   *ChangeLogAttachmentInfo   `json:"attachmentInfo"`
+	AttributeInfo    *ChangeLogAttributeInfo    `json:"attributeInfo"`
+	NotificationInfo *ChangeLogNotificationInfo `json:"notificationInfo"`
+}
+
+type ChangeLogAttachmentInfo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ChangeLogAttributeInfo struct {
+	ID     int `json:"id"`
+	TypeID int `json:"typeId"`
+}
+
+type ChangeLogNotificationInfo struct {
+	Type string `json:"type"`
 }
 
 func (s *Service) GetIssueList(query GetIssueListQuery) ([]Issue, error) {
@@ -148,15 +175,11 @@ func (s *Service) GetIssueList(query GetIssueListQuery) ([]Issue, error) {
 		urlParams.Add("resolutionId[]", strconv.Itoa(resolutionId))
 	}
 	urlParams.Add("parentChild", strconv.Itoa(query.ParentChild))
-	if query.Attachment {
-		urlParams.Add("attachment", "true")
-	} else {
-		urlParams.Add("attachment", "false")
+	if query.Attachment != nil {
+		urlParams.Add("attachment", strconv.FormatBool(*query.Attachment))
 	}
-	if query.SharedFile {
-		urlParams.Add("sharedFile", "true")
-	} else {
-		urlParams.Add("sharedFile", "false")
+	if query.SharedFile != nil {
+		urlParams.Add("sharedFile", strconv.FormatBool(*query.SharedFile))
 	}
 	urlParams.Add("sort", query.Sort)
 	urlParams.Add("order", query.Order)
@@ -200,6 +223,29 @@ func (s *Service) GetIssueList(query GetIssueListQuery) ([]Issue, error) {
 	return issues, nil
 }
 
+// GetAllIssues pages through GetIssueList from query.Offset until every matching issue is fetched.
+// Sort and Order are replaced with created asc, so issues updated while paging don't move between pages.
+func (s *Service) GetAllIssues(query GetIssueListQuery) ([]Issue, error) {
+	if query.Count <= 0 || query.Count > 100 {
+		query.Count = 100
+	}
+	query.Sort = "created"
+	query.Order = "asc"
+
+	var all []Issue
+	for {
+		issues, err := s.GetIssueList(query)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+		if len(issues) < query.Count {
+			return all, nil
+		}
+		query.Offset += len(issues)
+	}
+}
+
 func (s *Service) CountIssue(query GetIssueListQuery) (int, error) {
 	requestUrl := s.BaseUrl + "/api/v2/issues/count"
 	urlParams := url.Values{}
@@ -235,15 +281,11 @@ func (s *Service) CountIssue(query GetIssueListQuery) (int, error) {
 		urlParams.Add("resolutionId[]", strconv.Itoa(resolutionId))
 	}
 	urlParams.Add("parentChild", strconv.Itoa(query.ParentChild))
-	if query.Attachment {
-		urlParams.Add("attachment", "true")
-	} else {
-		urlParams.Add("attachment", "false")
+	if query.Attachment != nil {
+		urlParams.Add("attachment", strconv.FormatBool(*query.Attachment))
 	}
-	if query.SharedFile {
-		urlParams.Add("sharedFile", "true")
-	} else {
-		urlParams.Add("sharedFile", "false")
+	if query.SharedFile != nil {
+		urlParams.Add("sharedFile", strconv.FormatBool(*query.SharedFile))
 	}
 	urlParams.Add("sort", query.Sort)
 	urlParams.Add("order", query.Order)
@@ -314,3 +356,65 @@ func (s *Service) GetIssue(issueIdOrKey string) (Issue, error) {
 
 	return issue, nil
 }
+
+func (s *Service) GetIssueParticipants(issueIdOrKey string) ([]User, error) {
+	requestUrl := s.BaseUrl + "/api/v2/issues/" + issueIdOrKey + "/participants"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	err = json.Unmarshal(body, &users)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return users, nil
+}
+
+func (s *Service) GetCommentList(issueIdOrKey string, query GetCommentListQuery) ([]Comment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/issues/" + issueIdOrKey + "/comments"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.MinId != 0 {
+		urlParams.Add("minId", strconv.Itoa(query.MinId))
+	}
+	if query.MaxId != 0 {
+		urlParams.Add("maxId", strconv.Itoa(query.MaxId))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []Comment
+	err = json.Unmarshal(body, &comments)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return comments, nil
+}
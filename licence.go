@@ -0,0 +1,112 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"time"
+	"unsafe"
+)
+
+type Licence struct {
+	Active                            bool       `json:"active"`
+	AttachmentLimit                   int64      `json:"attachmentLimit"`
+	AttachmentLimitPerFile            int64      `json:"attachmentLimitPerFile"`
+	AttachmentNumLimit                int        `json:"attachmentNumLimit"`
+	Attribute                         bool       `json:"attribute"`
+	AttributeLimit                    int        `json:"attributeLimit"`
+	Burndown                          bool       `json:"burndown"`
+	CommentLimit                      int        `json:"commentLimit"`
+	ComponentLimit                    int        `json:"componentLimit"`
+	FileSharing                       bool       `json:"fileSharing"`
+	Gantt                             bool       `json:"gantt"`
+	Git                               bool       `json:"git"`
+	IssueLimit                        int        `json:"issueLimit"`
+	LicenceTypeID                     int        `json:"licenceTypeId"`
+	LimitDate                         *time.Time `json:"limitDate"`
+	NulabAccount                      bool       `json:"nulabAccount"`
+	ParentChild                       bool       `json:"parentChild"`
+	PostIssueByMail                   bool       `json:"postIssueByMail"`
+	ProjectGroup                      bool       `json:"projectGroup"`
+	ProjectLimit                      int        `json:"projectLimit"`
+	PullRequestAttachmentLimitPerFile int64      `json:"pullRequestAttachmentLimitPerFile"`
+	PullRequestAttachmentNumLimit     int        `json:"pullRequestAttachmentNumLimit"`
+	RemoteAddress                     bool       `json:"remoteAddress"`
+	RemoteAddressLimit                int        `json:"remoteAddressLimit"`
+	StartedOn                         *time.Time `json:"startedOn"`
+	StorageLimit                      int64      `json:"storageLimit"`
+	Subversion                        bool       `json:"subversion"`
+	SubversionExternal                bool       `json:"subversionExternal"`
+	UserLimit                         int        `json:"userLimit"` // 0: unlimited
+	VersionLimit                      int        `json:"versionLimit"`
+	WikiAttachment                    bool       `json:"wikiAttachment"`
+	WikiAttachmentLimitPerFile        int64      `json:"wikiAttachmentLimitPerFile"`
+	WikiAttachmentNumLimit            int        `json:"wikiAttachmentNumLimit"`
+}
+
+type SeatUsage struct {
+	Users     int
+	Limit     int // 0: unlimited
+	Available int // -1 when unlimited
+}
+
+func (s *Service) GetLicence() (Licence, error) {
+	requestUrl := s.BaseUrl + "/api/v2/space/licence"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var licence Licence
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return licence, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return licence, err
+	}
+
+	err = json.Unmarshal(body, &licence)
+	if err != nil {
+		return licence, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return licence, nil
+}
+
+// GetSeatUsage compares the number of users in the space with the licence's user limit.
+func (s *Service) GetSeatUsage() (SeatUsage, error) {
+	licence, err := s.GetLicence()
+	if err != nil {
+		return SeatUsage{}, err
+	}
+
+	users, err := s.GetUserList()
+	if err != nil {
+		return SeatUsage{}, err
+	}
+
+	return NewSeatUsage(licence, users), nil
+}
+
+func NewSeatUsage(licence Licence, users []User) SeatUsage {
+	seatUsage := SeatUsage{
+		Users:     len(users),
+		Limit:     licence.UserLimit,
+		Available: -1,
+	}
+	if seatUsage.Limit > 0 {
+		seatUsage.Available = seatUsage.Limit - seatUsage.Users
+		if seatUsage.Available < 0 {
+			seatUsage.Available = 0
+		}
+	}
+	return seatUsage
+}
+
+func (u SeatUsage) CanAdd(n int) bool {
+	return u.Limit == 0 || u.Available >= n
+}
@@ -0,0 +1,270 @@
+package notation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const escapeBase = 0xE000 // start of the Unicode private use area
+
+var (
+	markdownFenceRegexp    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^\\s`]*)")
+	markdownHeadingRegexp  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	markdownSetext1Regexp  = regexp.MustCompile(`^ {0,3}=+\s*$`)
+	markdownSetext2Regexp  = regexp.MustCompile(`^ {0,3}-+\s*$`)
+	markdownRuleRegexp     = regexp.MustCompile(`^ {0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	markdownListRegexp     = regexp.MustCompile(`^(\s*)([-*+]|[0-9]+[.)])\s+(.*)$`)
+	markdownQuoteRegexp    = regexp.MustCompile(`^ {0,3}>\s?(.*)$`)
+	markdownTableSepRegexp = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?\s*$`)
+	markdownCodeSpanRegexp = regexp.MustCompile("(`+)(.+?)(`+)")
+	markdownEscapeRegexp   = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+	markdownLinkDestRegexp = regexp.MustCompile(`^(\S*)(?:\s+["'(].*)?$`)
+	backlogEscaper         = strings.NewReplacer("''", "'&#39;", "%%", "%&#37;", "[[", "[&#91;")
+	markdownInlineRegexp   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]*)\)` +
+		`|!\[([^\]]*)\]\[([^\]]*)\]` +
+		`|\[([^\]]+)\]\(([^)]*)\)` +
+		`|<((?:https?|ftp)://[^>\s]+|mailto:[^>\s]+)>` +
+		`|\*\*(.+?)\*\*` +
+		`|__(.+?)__` +
+		`|\*([^*\s](?:.*?[^*\s])?)\*` +
+		`|_([^_\s](?:.*?[^_\s])?)_` +
+		`|~~(.+?)~~` +
+		`|<br\s*/?>`)
+)
+
+// ToBacklog converts CommonMark, including GFM tables and strikethrough, into Backlog wiki notation.
+// Plain text that Backlog would read as notation is protected with character references such as &#43;.
+func ToBacklog(src string, options Options) string {
+	lines := splitLines(src)
+	var out []string
+	var listIndents []int
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := markdownListRegexp.FindStringSubmatch(line); m != nil && !markdownRuleRegexp.MatchString(line) {
+			indent := len(strings.Replace(m[1], "\t", "    ", -1))
+			for len(listIndents) > 0 && indent < listIndents[len(listIndents)-1] {
+				listIndents = listIndents[:len(listIndents)-1]
+			}
+			if len(listIndents) == 0 || indent > listIndents[len(listIndents)-1] {
+				listIndents = append(listIndents, indent)
+			}
+			marker := "-"
+			if m[2][0] >= '0' && m[2][0] <= '9' {
+				marker = "+"
+			}
+			out = append(out, strings.Repeat(marker, len(listIndents))+" "+escapeBacklogBlockStart(inlineToBacklog(m[3], options)))
+			continue
+		}
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			listIndents = nil
+		}
+
+		if m := markdownFenceRegexp.FindStringSubmatch(line); m != nil {
+			if m[2] != "" {
+				out = append(out, "{code:"+m[2]+"}")
+			} else {
+				out = append(out, "{code}")
+			}
+			for i++; i < len(lines); i++ {
+				trimmed := strings.TrimSpace(lines[i])
+				if strings.HasPrefix(trimmed, m[1]) && strings.Trim(trimmed, m[1][:1]) == "" {
+					break
+				}
+				out = append(out, lines[i])
+			}
+			out = append(out, "{/code}")
+			continue
+		}
+
+		if i+1 < len(lines) && strings.Contains(line, "|") && markdownTableSepRegexp.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-") {
+			header := markdownTableCells(line)
+			if strings.Join(header, "") != "" {
+				out = append(out, backlogTableRow(header, options)+"h")
+			}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				out = append(out, backlogTableRow(markdownTableCells(lines[i]), options))
+			}
+			i--
+			continue
+		}
+
+		if i+1 < len(lines) && strings.TrimSpace(line) != "" && !markdownQuoteRegexp.MatchString(line) {
+			if markdownSetext1Regexp.MatchString(lines[i+1]) {
+				out = append(out, "* "+inlineToBacklog(strings.TrimSpace(line), options))
+				i++
+				continue
+			}
+			if markdownSetext2Regexp.MatchString(lines[i+1]) && !markdownRuleRegexp.MatchString(line) {
+				out = append(out, "** "+inlineToBacklog(strings.TrimSpace(line), options))
+				i++
+				continue
+			}
+		}
+
+		switch {
+		case markdownRuleRegexp.MatchString(line):
+			out = append(out, "----")
+		case markdownHeadingRegexp.MatchString(line):
+			m := markdownHeadingRegexp.FindStringSubmatch(line)
+			out = append(out, strings.Repeat("*", len(m[1]))+" "+inlineToBacklog(m[2], options))
+		case markdownQuoteRegexp.MatchString(line):
+			m := markdownQuoteRegexp.FindStringSubmatch(line)
+			out = append(out, strings.TrimRight("> "+inlineToBacklog(m[1], options), " "))
+		default:
+			out = append(out, escapeBacklogBlockStart(inlineToBacklog(line, options)))
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func markdownTableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, "\\|") {
+		row = row[:len(row)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		if row[i] == '\\' && i+1 < len(row) && row[i+1] == '|' {
+			cell.WriteByte('|')
+			i++
+			continue
+		}
+		if row[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			continue
+		}
+		cell.WriteByte(row[i])
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func backlogTableRow(cells []string, options Options) string {
+	converted := make([]string, len(cells))
+	for i, cell := range cells {
+		converted[i] = inlineToBacklog(cell, options)
+	}
+	return "|" + strings.Join(converted, "|") + "|"
+}
+
+func inlineToBacklog(text string, options Options) string {
+	// code spans are copied verbatim, everything between them is converted
+	var b strings.Builder
+	last := 0
+	for _, loc := range markdownCodeSpanRegexp.FindAllStringSubmatchIndex(text, -1) {
+		if group(text, loc, 1) != group(text, loc, 3) {
+			continue
+		}
+		b.WriteString(spanToBacklog(text[last:loc[0]], options))
+		b.WriteString("{code}" + strings.TrimSpace(group(text, loc, 2)) + "{/code}")
+		last = loc[1]
+	}
+	b.WriteString(spanToBacklog(text[last:], options))
+	return b.String()
+}
+
+func spanToBacklog(text string, options Options) string {
+	// escaped characters are moved out of the way of the emphasis patterns and restored afterwards
+	text = markdownEscapeRegexp.ReplaceAllStringFunc(text, func(escaped string) string {
+		return string(escapeBase + rune(escaped[1]))
+	})
+	text = replaceAllSubmatch(markdownInlineRegexp, text, func(src string, loc []int) string {
+		match := src[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(match, "!["):
+			if loc[4] >= 0 {
+				return "#image(" + markdownLinkDestination(group(src, loc, 2)) + ")"
+			}
+			return "#image(" + group(src, loc, 4) + ")"
+		case strings.HasPrefix(match, "["):
+			return linkToBacklog(group(src, loc, 5), markdownLinkDestination(group(src, loc, 6)), options)
+		case strings.HasPrefix(match, "<br"):
+			return "&br;"
+		case strings.HasPrefix(match, "<"):
+			return group(src, loc, 7)
+		case strings.HasPrefix(match, "**"):
+			return "''" + spanToBacklog(group(src, loc, 8), options) + "''"
+		case strings.HasPrefix(match, "__"):
+			if isIntraword(src, loc) {
+				return match
+			}
+			return "''" + spanToBacklog(group(src, loc, 9), options) + "''"
+		case strings.HasPrefix(match, "*"):
+			return "'''" + spanToBacklog(group(src, loc, 10), options) + "'''"
+		case strings.HasPrefix(match, "_"):
+			if isIntraword(src, loc) {
+				return match
+			}
+			return "'''" + spanToBacklog(group(src, loc, 11), options) + "'''"
+		default:
+			return "%%" + spanToBacklog(group(src, loc, 12), options) + "%%"
+		}
+	}, func(plain string) string {
+		return backlogEscaper.Replace(restoreEscapes(plain))
+	})
+	return restoreEscapes(text)
+}
+
+func restoreEscapes(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r > escapeBase && r < escapeBase+0x80 {
+			return r - escapeBase
+		}
+		return r
+	}, text)
+}
+
+func linkToBacklog(label string, target string, options Options) string {
+	plain := restoreEscapes(label)
+	if issueKeyRegexp.MatchString(plain) && issueKeyRegexp.FindString(plain) == plain {
+		if target == options.issueURL(plain) || strings.HasSuffix(target, "/view/"+plain) {
+			return plain
+		}
+	}
+	if plain == target {
+		if hasScheme(target) {
+			return target
+		}
+		return "[[" + target + "]]"
+	}
+	return "[[" + spanToBacklog(label, options) + ">" + target + "]]"
+}
+
+func markdownLinkDestination(dest string) string {
+	dest = strings.TrimSpace(dest)
+	if strings.HasPrefix(dest, "<") {
+		if end := strings.Index(dest, ">"); end >= 0 {
+			return dest[1:end]
+		}
+	}
+	if m := markdownLinkDestRegexp.FindStringSubmatch(dest); m != nil {
+		return m[1]
+	}
+	return dest
+}
+
+func isIntraword(src string, loc []int) bool {
+	return loc[0] > 0 && isWordByte(src[loc[0]-1]) || loc[1] < len(src) && isWordByte(src[loc[1]])
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// escapeBacklogBlockStart writes the first character of a line that Backlog would read as block markup
+// as a character reference.
+func escapeBacklogBlockStart(line string) string {
+	for _, re := range []*regexp.Regexp{backlogCodeRegexp, backlogQuoteRegexp, backlogHeadingRegexp, backlogRuleRegexp, backlogBulletRegexp, backlogNumberRegexp, backlogTableRegexp, backlogBlockquoteRegexp} {
+		if re.MatchString(line) {
+			return "&#" + strconv.Itoa(int(line[0])) + ";" + line[1:]
+		}
+	}
+	return line
+}
@@ -0,0 +1,255 @@
+package notation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	backlogCodeRegexp       = regexp.MustCompile(`^\{code(?::([^}]*))?\}(.*)$`)
+	backlogQuoteRegexp      = regexp.MustCompile(`^\{quote\}(.*)$`)
+	backlogHeadingRegexp    = regexp.MustCompile(`^(\*{1,6})\s*([^*\s].*)$`)
+	backlogRuleRegexp       = regexp.MustCompile(`^-{4,}\s*$`)
+	backlogBulletRegexp     = regexp.MustCompile(`^(-+)\s*([^-\s].*)$`)
+	backlogNumberRegexp     = regexp.MustCompile(`^(\++)\s*([^+\s].*)$`)
+	backlogTableRegexp      = regexp.MustCompile(`^\|.*\|h?\s*$`)
+	backlogBlockquoteRegexp = regexp.MustCompile(`^>`)
+	backlogInlineRegexp     = regexp.MustCompile(`\{code\}(.*?)\{/code\}` +
+		`|\[\[(.+?)\]\]` +
+		`|#(?:image|thumbnail)\(([^)]*)\)` +
+		`|&color\([^)]*\)\s*\{(.*?)\}` +
+		`|'''(.+?)'''` +
+		`|''(.+?)''` +
+		`|%%(.+?)%%` +
+		`|&br;` +
+		`|(https?://[^\s<>]+)` +
+		`|&#([0-9]+);` +
+		`|` + issueKeyRegexp.String())
+	leadingMarkerRegexp = regexp.MustCompile(`^(\s*)([-+*])(\s|$)|^(\s*)([-=])([-=]*\s*)$`)
+	leadingNumberRegexp = regexp.MustCompile(`^(\s*[0-9]+)([.)])(\s|$)`)
+	markdownEscaper     = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`, "`", "\\`", "~", `\~`)
+)
+
+// ToMarkdown converts Backlog wiki notation into CommonMark, using GFM tables and strikethrough
+// where CommonMark has no equivalent.
+func ToMarkdown(src string, options Options) string {
+	return strings.Join(linesToMarkdown(splitLines(src), options), "\n")
+}
+
+func linesToMarkdown(lines []string, options Options) []string {
+	var out []string
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := backlogCodeRegexp.FindStringSubmatch(line); m != nil && !strings.Contains(m[2], "{/code}") {
+			out = append(out, "```"+strings.TrimSpace(m[1]))
+			if m[2] != "" {
+				out = append(out, m[2])
+			}
+			for i++; i < len(lines); i++ {
+				if end := strings.Index(lines[i], "{/code}"); end >= 0 {
+					if lines[i][:end] != "" {
+						out = append(out, lines[i][:end])
+					}
+					break
+				}
+				out = append(out, lines[i])
+			}
+			out = append(out, "```")
+			continue
+		}
+
+		if m := backlogQuoteRegexp.FindStringSubmatch(line); m != nil {
+			var quoted []string
+			if m[1] != "" {
+				quoted = append(quoted, m[1])
+			}
+			for i++; i < len(lines); i++ {
+				if end := strings.Index(lines[i], "{/quote}"); end >= 0 {
+					if lines[i][:end] != "" {
+						quoted = append(quoted, lines[i][:end])
+					}
+					break
+				}
+				quoted = append(quoted, lines[i])
+			}
+			for _, q := range linesToMarkdown(quoted, options) {
+				out = append(out, strings.TrimRight("> "+q, " "))
+			}
+			continue
+		}
+
+		if backlogTableRegexp.MatchString(line) {
+			var rows []string
+			for ; i < len(lines) && backlogTableRegexp.MatchString(lines[i]); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			out = append(out, tableToMarkdown(rows, options)...)
+			continue
+		}
+
+		switch {
+		case backlogRuleRegexp.MatchString(line):
+			out = append(out, "---")
+		case backlogHeadingRegexp.MatchString(line):
+			m := backlogHeadingRegexp.FindStringSubmatch(line)
+			out = append(out, strings.Repeat("#", len(m[1]))+" "+inlineToMarkdown(m[2], options))
+		case backlogBulletRegexp.MatchString(line):
+			m := backlogBulletRegexp.FindStringSubmatch(line)
+			out = append(out, strings.Repeat("  ", len(m[1])-1)+"- "+escapeBlockStart(inlineToMarkdown(m[2], options)))
+		case backlogNumberRegexp.MatchString(line):
+			m := backlogNumberRegexp.FindStringSubmatch(line)
+			out = append(out, strings.Repeat("   ", len(m[1])-1)+"1. "+escapeBlockStart(inlineToMarkdown(m[2], options)))
+		default:
+			out = append(out, escapeBlockStart(inlineToMarkdown(line, options)))
+		}
+	}
+
+	return out
+}
+
+func tableToMarkdown(rows []string, options Options) []string {
+	var out []string
+
+	for i, row := range rows {
+		header := strings.HasSuffix(row, "|h")
+		row = strings.TrimSuffix(row, "h")
+		cells := strings.Split(row[1:len(row)-1], "|")
+		for j := range cells {
+			cells[j] = inlineToMarkdown(strings.TrimSpace(cells[j]), options)
+		}
+
+		if i == 0 && !header {
+			empty := make([]string, len(cells))
+			out = append(out, markdownTableRow(empty), markdownTableSeparator(len(cells)))
+		}
+		out = append(out, markdownTableRow(cells))
+		if i == 0 && header {
+			out = append(out, markdownTableSeparator(len(cells)))
+		}
+	}
+
+	return out
+}
+
+func markdownTableRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+func markdownTableSeparator(n int) string {
+	separators := make([]string, n)
+	for i := range separators {
+		separators[i] = "---"
+	}
+	return markdownTableRow(separators)
+}
+
+func inlineToMarkdown(text string, options Options) string {
+	// plain text between the matches is escaped so that it can't turn into Markdown or HTML
+	return replaceAllSubmatch(backlogInlineRegexp, text, func(src string, loc []int) string {
+		return matchToMarkdown(src, loc, options)
+	}, markdownEscaper.Replace)
+}
+
+func matchToMarkdown(src string, loc []int, options Options) string {
+	match := src[loc[0]:loc[1]]
+	switch {
+	case strings.HasPrefix(match, "{code}"):
+		return "`" + group(src, loc, 1) + "`"
+	case strings.HasPrefix(match, "[["):
+		return linkToMarkdown(group(src, loc, 2), options)
+	case strings.HasPrefix(match, "#"):
+		name := strings.TrimSpace(group(src, loc, 3))
+		return "![" + markdownEscaper.Replace(name) + "](" + markdownURL(options.attachmentURL(name)) + ")"
+	case strings.HasPrefix(match, "&color"):
+		return inlineToMarkdown(group(src, loc, 4), options)
+	case strings.HasPrefix(match, "'''"):
+		return "*" + inlineToMarkdown(group(src, loc, 5), options) + "*"
+	case strings.HasPrefix(match, "''"):
+		return "**" + inlineToMarkdown(group(src, loc, 6), options) + "**"
+	case strings.HasPrefix(match, "%%"):
+		return "~~" + inlineToMarkdown(group(src, loc, 7), options) + "~~"
+	case match == "&br;":
+		return "<br>"
+	case group(src, loc, 8) != "":
+		// bare URLs are left alone so that issue keys inside them are not linked
+		return match
+	case group(src, loc, 9) != "":
+		// character references are how ToBacklog protects text from Backlog notation
+		n, err := strconv.Atoi(group(src, loc, 9))
+		if err != nil || n <= 0 || n > unicode.MaxRune {
+			return markdownEscaper.Replace(match)
+		}
+		return markdownEscaper.Replace(string(rune(n)))
+	default:
+		url := options.issueURL(match)
+		if url == "" {
+			return match
+		}
+		return "[" + match + "](" + markdownURL(url) + ")"
+	}
+}
+
+// escapeBlockStart keeps a converted line from starting a list or a setext heading.
+func escapeBlockStart(line string) string {
+	line = leadingMarkerRegexp.ReplaceAllString(line, "${1}${4}\\${2}${5}${3}${6}")
+	return leadingNumberRegexp.ReplaceAllString(line, "${1}\\${2}${3}")
+}
+
+// linkToMarkdown converts the inside of [[...]], which is either a page name, a URL,
+// or a label followed by ">" or ":" and the target.
+func linkToMarkdown(link string, options Options) string {
+	label, target := link, link
+	if i := strings.Index(link, ">"); i >= 0 {
+		label, target = link[:i], link[i+1:]
+	} else {
+		for i := strings.Index(link, ":"); i >= 0; {
+			if hasScheme(link[i+1:]) {
+				label, target = link[:i], link[i+1:]
+				break
+			}
+			next := strings.Index(link[i+1:], ":")
+			if next < 0 {
+				break
+			}
+			i += next + 1
+		}
+	}
+	label, target = strings.TrimSpace(label), strings.TrimSpace(target)
+
+	if hasScheme(target) && label == target {
+		return "<" + target + ">"
+	}
+	return "[" + inlineToMarkdown(label, Options{AttachmentURL: options.AttachmentURL}) + "](" + markdownURL(target) + ")"
+}
+
+func markdownURL(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + url + ">"
+	}
+	return url
+}
+
+// replaceAllSubmatch replaces every match of re with fn and the text between the matches with plain.
+func replaceAllSubmatch(re *regexp.Regexp, src string, fn func(src string, loc []int) string, plain func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(src, -1) {
+		b.WriteString(plain(src[last:loc[0]]))
+		b.WriteString(fn(src, loc))
+		last = loc[1]
+	}
+	b.WriteString(plain(src[last:]))
+	return b.String()
+}
+
+func group(src string, loc []int, n int) string {
+	if loc[2*n] < 0 {
+		return ""
+	}
+	return src[loc[2*n]:loc[2*n+1]]
+}
@@ -0,0 +1,57 @@
+package notation
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	FormatBacklog  = "backlog"
+	FormatMarkdown = "markdown"
+)
+
+type Options struct {
+	IssueURL      func(issueKey string) string // issue keys are turned into links when set
+	AttachmentURL func(name string) string     // image macros point at the bare file name when nil
+}
+
+var issueKeyRegexp = regexp.MustCompile(`\b[A-Z][A-Z0-9_]*-[0-9]+\b`)
+
+// Convert translates src between the two values of Project.TextFormattingRule.
+func Convert(src string, from string, to string, options Options) (string, error) {
+	if from == to {
+		return src, nil
+	}
+	switch {
+	case from == FormatBacklog && to == FormatMarkdown:
+		return ToMarkdown(src, options), nil
+	case from == FormatMarkdown && to == FormatBacklog:
+		return ToBacklog(src, options), nil
+	default:
+		return "", errors.New("unsupported conversion: " + from + " to " + to)
+	}
+}
+
+func (o Options) issueURL(issueKey string) string {
+	if o.IssueURL == nil {
+		return ""
+	}
+	return o.IssueURL(issueKey)
+}
+
+func (o Options) attachmentURL(name string) string {
+	if o.AttachmentURL == nil {
+		return name
+	}
+	return o.AttachmentURL(name)
+}
+
+func splitLines(src string) []string {
+	src = strings.Replace(src, "\r\n", "\n", -1)
+	return strings.Split(src, "\n")
+}
+
+func hasScheme(link string) bool {
+	return strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:")
+}
@@ -0,0 +1,108 @@
+package notation
+
+import "testing"
+
+func TestConvert(t *testing.T) {
+	options := Options{
+		IssueURL: func(issueKey string) string {
+			return "https://example.backlog.jp/view/" + issueKey
+		},
+	}
+
+	tests := []struct {
+		name     string
+		backlog  string
+		markdown string
+		back     string // result of converting markdown back, when it differs from backlog
+	}{
+		{"heading", "* Title\n** Section", "# Title\n## Section", ""},
+		{"bullet list", "- one\n-- two", "- one\n  - two", ""},
+		{"numbered list", "+ one\n++ two", "1. one\n   1. two", ""},
+		{"table", "|a|b|h\n|1|2|", "| a | b |\n| --- | --- |\n| 1 | 2 |", ""},
+		{"code block", "{code:go}\nx := a * b\n{/code}", "```go\nx := a * b\n```", ""},
+		{"inline code", "run {code}go test{/code} now", "run `go test` now", ""},
+		{"emphasis", "''bold'' '''italic''' %%strike%%", "**bold** *italic* ~~strike~~", ""},
+		{"link", "[[Go>https://golang.org]]", "[Go](https://golang.org)", ""},
+		{"page link", "[[Some_Page]]", "[Some\\_Page](Some_Page)", ""},
+		{"url link", "[[https://golang.org]]", "<https://golang.org>", "https://golang.org"},
+		{"image", "#image(a_b.png)", "![a\\_b.png](a_b.png)", ""},
+		{"thumbnail", "#thumbnail(a.png)", "![a.png](a.png)", "#image(a.png)"},
+		{"issue key", "see PROJ-1.", "see [PROJ-1](https://example.backlog.jp/view/PROJ-1).", ""},
+		{"issue key in url", "see https://example.backlog.jp/view/PROJ-1", "see https://example.backlog.jp/view/PROJ-1", ""},
+		{"hash", "# 1 priority", "\\# 1 priority", ""},
+		{"number", "1. not a list", "1\\. not a list", ""},
+		{"asterisks", "price *10* each", "price \\*10\\* each", ""},
+		{"multiplication", "2*3*4", "2\\*3\\*4", ""},
+		{"html", "<img src=x onerror=alert(1)>", "\\<img src=x onerror=alert(1)\\>", ""},
+		{"underscores", "snake_case_name", "snake\\_case\\_name", ""},
+		{"backslash", `C:\temp`, `C:\\temp`, ""},
+		{"dashes", "---", "\\---", ""},
+		{"rule", "----", "---", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown := ToMarkdown(tt.backlog, options)
+			if markdown != tt.markdown {
+				t.Errorf("ToMarkdown(%q) = %q, want %q", tt.backlog, markdown, tt.markdown)
+			}
+
+			back := tt.back
+			if back == "" {
+				back = tt.backlog
+			}
+			if got := ToBacklog(markdown, options); got != back {
+				t.Errorf("ToBacklog(%q) = %q, want %q", markdown, got, back)
+			}
+		})
+	}
+}
+
+func TestConvertFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		backlog  string
+		back     string // result of converting backlog back, when it differs from markdown
+	}{
+		{"plus", "+1 for this idea", "&#43;1 for this idea", ""},
+		{"dashes", "-- not a list", "&#45;- not a list", ""},
+		{"star", "\\*\\* not a heading", "&#42;* not a heading", "\\*\\* not a heading"},
+		{"pipe", "| a | b |", "&#124; a | b |", ""},
+		{"quote character", "\\> not a quote", "&#62; not a quote", ""},
+		{"code macro", "{code} is a macro", "&#123;code} is a macro", ""},
+		{"bold quotes", "It's ''quoted'' text", "It's '&#39;quoted'&#39; text", ""},
+		{"strike percent", "100%% sure", "100%&#37; sure", ""},
+		{"link brackets", "see [[page]]", "see [&#91;page]]", "see \\[\\[page\\]\\]"},
+		{"escaped emphasis", "\\*not\\* italic", "&#42;not* italic", ""},
+		{"escaped emphasis inside", "a \\*not\\* italic", "a *not* italic", ""},
+		{"list item", "- +1", "- &#43;1", ""},
+		{"heading", "# Title", "* Title", ""},
+		{"bold", "**bold**", "''bold''", ""},
+		{"number in text", "1.5 hours", "1.5 hours", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backlog := ToBacklog(tt.markdown, Options{})
+			if backlog != tt.backlog {
+				t.Errorf("ToBacklog(%q) = %q, want %q", tt.markdown, backlog, tt.backlog)
+			}
+
+			back := tt.back
+			if back == "" {
+				back = tt.markdown
+			}
+			if got := ToMarkdown(backlog, Options{}); got != back {
+				t.Errorf("ToMarkdown(%q) = %q, want %q", backlog, got, back)
+			}
+		})
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	_, err := Convert("x", FormatBacklog, "html", Options{})
+	if err == nil {
+		t.Error("Convert to html: expected an error")
+	}
+}
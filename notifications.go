@@ -10,17 +10,93 @@ import (
 	"unsafe"
 )
 
+type NotificationReason int
+
+const (
+	NotificationReasonAssigned             NotificationReason = 1
+	NotificationReasonCommented            NotificationReason = 2
+	NotificationReasonIssueCreated         NotificationReason = 3
+	NotificationReasonIssueUpdated         NotificationReason = 4
+	NotificationReasonFileAttached         NotificationReason = 5
+	NotificationReasonProjectUserAdded     NotificationReason = 6
+	NotificationReasonOther                NotificationReason = 9
+	NotificationReasonPullRequestAssigned  NotificationReason = 10
+	NotificationReasonPullRequestCommented NotificationReason = 11
+	NotificationReasonPullRequestAdded     NotificationReason = 12
+	NotificationReasonPullRequestUpdated   NotificationReason = 13
+)
+
+func (r NotificationReason) String() string {
+	switch r {
+	case NotificationReasonAssigned:
+		return "Assigned"
+	case NotificationReasonCommented:
+		return "Commented"
+	case NotificationReasonIssueCreated:
+		return "IssueCreated"
+	case NotificationReasonIssueUpdated:
+		return "IssueUpdated"
+	case NotificationReasonFileAttached:
+		return "FileAttached"
+	case NotificationReasonProjectUserAdded:
+		return "ProjectUserAdded"
+	case NotificationReasonOther:
+		return "Other"
+	case NotificationReasonPullRequestAssigned:
+		return "PullRequestAssigned"
+	case NotificationReasonPullRequestCommented:
+		return "PullRequestCommented"
+	case NotificationReasonPullRequestAdded:
+		return "PullRequestAdded"
+	case NotificationReasonPullRequestUpdated:
+		return "PullRequestUpdated"
+	default:
+		return "NotificationReason(" + strconv.Itoa(int(r)) + ")"
+	}
+}
+
+func (r NotificationReason) In(reasons ...NotificationReason) bool {
+	for _, reason := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (r NotificationReason) IsPullRequest() bool {
+	return r.In(NotificationReasonPullRequestAssigned, NotificationReasonPullRequestCommented, NotificationReasonPullRequestAdded, NotificationReasonPullRequestUpdated)
+}
+
+func FilterNotificationsByReason(notifications []Notification, reasons ...NotificationReason) []Notification {
+	var filtered []Notification
+	for _, notification := range notifications {
+		if notification.Reason.In(reasons...) {
+			filtered = append(filtered, notification)
+		}
+	}
+	return filtered
+}
+
+type GetNotificationQuery struct {
+	MinId    int
+	MaxId    int
+	Count    int    // default: 20, max: 100
+	Order    string // asc, desc (default)
+	SenderId int
+}
+
 type CountNotificationQuery struct {
-	AlreadyRead         bool // default: false
-	ResourceAlreadyRead bool // default: false
+	AlreadyRead         *bool // nil: not filtered
+	ResourceAlreadyRead *bool // nil: not filtered
 }
 
 type Notification struct {
-	ID                  int     `json:"id"`
-	AlreadyRead         bool    `json:"alreadyRead"`
-	Reason              int     `json:"reason"`
-	ResourceAlreadyRead bool    `json:"resourceAlreadyRead"`
-	Project             Project `json:"project"`
+	ID                  int                `json:"id"`
+	AlreadyRead         bool               `json:"alreadyRead"`
+	Reason              NotificationReason `json:"reason"`
+	ResourceAlreadyRead bool               `json:"resourceAlreadyRead"`
+	Project             Project            `json:"project"`
 	Issue               struct {
 		ID        int    `json:"id"`
 		ProjectID int    `json:"projectId"`
@@ -63,28 +139,34 @@ type Notification struct {
 		CustomFields   []interface{} `json:"customFields"`
 		Attachments    []Attachment  `json:"attachments"`
 		SharedFiles    []SharedFile  `json:"sharedFiles"`
-		Stars          []interface{} `json:"stars"`
+		Stars          []Star        `json:"stars"`
 	} `json:"issue"`
-	Comment struct {
-		ID            int           `json:"id"`
-		Content       string        `json:"content"`
-		ChangeLog     interface{}   `json:"changeLog"`
-		CreatedUser   User          `json:"createdUser"`
-		Created       time.Time     `json:"created"`
-		Updated       time.Time     `json:"updated"`
-		Stars         []interface{} `json:"stars"`
-		Notifications []interface{} `json:"notifications"`
-	} `json:"comment"`
-	PullRequest        interface{} `json:"pullRequest"`
-	PullRequestComment interface{} `json:"pullRequestComment"`
-	Sender             User        `json:"sender"`
-	Created            time.Time   `json:"created"`
-}
-
-func (s *Service) GetNotification() ([]Notification, error) {
+	Comment            Comment             `json:"comment"`
+	PullRequest        *PullRequest        `json:"pullRequest"`
+	PullRequestComment *PullRequestComment `json:"pullRequestComment"`
+	Sender             User                `json:"sender"`
+	Created            time.Time           `json:"created"`
+}
+
+func (s *Service) GetNotification(query GetNotificationQuery) ([]Notification, error) {
 	requestUrl := s.BaseUrl + "/api/v2/notifications"
 	urlParams := url.Values{}
 	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.MinId != 0 {
+		urlParams.Add("minId", strconv.Itoa(query.MinId))
+	}
+	if query.MaxId != 0 {
+		urlParams.Add("maxId", strconv.Itoa(query.MaxId))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+	if query.SenderId != 0 {
+		urlParams.Add("senderId", strconv.Itoa(query.SenderId))
+	}
 
 	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
 	if err != nil {
@@ -106,20 +188,114 @@ func (s *Service) GetNotification() ([]Notification, error) {
 	return notifications, nil
 }
 
+// NotificationIterator pages through every notification matching a query. Notifications with the ID
+// MinId or MaxId are skipped, so MinId can be set to the last seen ID.
+type NotificationIterator struct {
+	s         *Service
+	query     GetNotificationQuery
+	page      []Notification
+	current   Notification
+	lastId    int
+	inclusive bool
+	done      bool
+	err       error
+}
+
+func (s *Service) NewNotificationIterator(query GetNotificationQuery) *NotificationIterator {
+	if query.Count == 0 {
+		query.Count = 100
+	}
+	it := &NotificationIterator{s: s, query: query}
+	if query.Order == "asc" {
+		it.lastId = query.MinId
+	} else {
+		it.lastId = query.MaxId
+	}
+	return it
+}
+
+func (it *NotificationIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+func (it *NotificationIterator) fetch() {
+	asc := it.query.Order == "asc"
+	query := it.query
+	if it.lastId != 0 {
+		bound := it.lastId
+		if it.inclusive {
+			// a full page without anything new showed that the bounds are inclusive, so step past them
+			if asc {
+				bound++
+			} else {
+				bound--
+			}
+		}
+		if asc {
+			query.MinId = bound
+		} else {
+			query.MaxId = bound
+		}
+	}
+
+	notifications, err := it.s.GetNotification(query)
+	if err != nil {
+		it.err = err
+		return
+	}
+	if len(notifications) < query.Count {
+		it.done = true
+	}
+
+	// minId and maxId may be inclusive, so anything already returned or outside the query is skipped
+	for _, notification := range notifications {
+		if it.lastId != 0 && (asc && notification.ID <= it.lastId || !asc && notification.ID >= it.lastId) {
+			continue
+		}
+		if asc && it.query.MaxId != 0 && notification.ID >= it.query.MaxId || !asc && it.query.MinId != 0 && notification.ID <= it.query.MinId {
+			continue
+		}
+		it.page = append(it.page, notification)
+	}
+
+	if len(it.page) == 0 {
+		if !it.done && !it.inclusive {
+			it.inclusive = true
+			return
+		}
+		it.done = true
+		return
+	}
+	it.lastId = it.page[len(it.page)-1].ID
+}
+
+func (it *NotificationIterator) Notification() Notification {
+	return it.current
+}
+
+func (it *NotificationIterator) Err() error {
+	return it.err
+}
+
 func (s *Service) CountNotification(query CountNotificationQuery) (int, error) {
 	requestUrl := s.BaseUrl + "/api/v2/notifications/count"
 	urlParams := url.Values{}
 	urlParams.Add("apiKey", s.Config.ApiKey)
 
-	if query.AlreadyRead {
-		urlParams.Add("alreadyRead", "true")
-	} else {
-		urlParams.Add("alreadyRead", "false")
+	if query.AlreadyRead != nil {
+		urlParams.Add("alreadyRead", strconv.FormatBool(*query.AlreadyRead))
 	}
-	if query.ResourceAlreadyRead {
-		urlParams.Add("resourceAlreadyRead", "true")
-	} else {
-		urlParams.Add("resourceAlreadyRead", "false")
+	if query.ResourceAlreadyRead != nil {
+		urlParams.Add("resourceAlreadyRead", strconv.FormatBool(*query.ResourceAlreadyRead))
 	}
 
 	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
@@ -0,0 +1,96 @@
+package backlog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// notificationServer serves the notifications 1 to n. inclusive decides whether minId and maxId
+// include the notification with that ID.
+func notificationServer(t *testing.T, n int, inclusive bool) (*Service, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		minId, _ := strconv.Atoi(q.Get("minId"))
+		maxId, _ := strconv.Atoi(q.Get("maxId"))
+		count, err := strconv.Atoi(q.Get("count"))
+		if err != nil {
+			count = 20
+		}
+
+		var notifications []map[string]int
+		for i := 1; i <= n; i++ {
+			id := i
+			if q.Get("order") != "asc" {
+				id = n + 1 - i
+			}
+			if minId != 0 && (id < minId || !inclusive && id == minId) {
+				continue
+			}
+			if maxId != 0 && (id > maxId || !inclusive && id == maxId) {
+				continue
+			}
+			if len(notifications) == count {
+				break
+			}
+			notifications = append(notifications, map[string]int{"id": id})
+		}
+		if notifications == nil {
+			notifications = []map[string]int{}
+		}
+		json.NewEncoder(w).Encode(notifications)
+	}))
+
+	s, err := NewClient(Configure{SpaceId: "example", ApiKey: "key"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.BaseUrl = srv.URL
+	return s, srv.Close
+}
+
+func TestNotificationIterator(t *testing.T) {
+	tests := []struct {
+		name  string
+		query GetNotificationQuery
+		want  []int
+	}{
+		{"desc", GetNotificationQuery{Count: 2}, []int{7, 6, 5, 4, 3, 2, 1}},
+		{"desc count 1", GetNotificationQuery{Count: 1}, []int{7, 6, 5, 4, 3, 2, 1}},
+		{"desc page size", GetNotificationQuery{Count: 7}, []int{7, 6, 5, 4, 3, 2, 1}},
+		{"desc max", GetNotificationQuery{Count: 2, MaxId: 5}, []int{4, 3, 2, 1}},
+		{"desc min", GetNotificationQuery{Count: 2, MinId: 3}, []int{7, 6, 5, 4}},
+		{"asc", GetNotificationQuery{Count: 3, Order: "asc"}, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"asc count 1 after last seen", GetNotificationQuery{Count: 1, Order: "asc", MinId: 4}, []int{5, 6, 7}},
+		{"asc min and max", GetNotificationQuery{Count: 2, Order: "asc", MinId: 2, MaxId: 6}, []int{3, 4, 5}},
+		{"asc nothing new", GetNotificationQuery{Count: 1, Order: "asc", MinId: 7}, nil},
+	}
+
+	for _, inclusive := range []bool{true, false} {
+		for _, tt := range tests {
+			name := tt.name + " exclusive"
+			if inclusive {
+				name = tt.name + " inclusive"
+			}
+			t.Run(name, func(t *testing.T) {
+				s, closeServer := notificationServer(t, 7, inclusive)
+				defer closeServer()
+
+				var got []int
+				it := s.NewNotificationIterator(tt.query)
+				for it.Next() {
+					got = append(got, it.Notification().ID)
+				}
+				if it.Err() != nil {
+					t.Fatal(it.Err())
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package backlog
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NotificationStore persists the highest notification ID a watcher has handled.
+type NotificationStore interface {
+	LoadLastId() (int, error)
+	SaveLastId(id int) error
+}
+
+type MemoryNotificationStore struct {
+	mu     sync.Mutex
+	lastId int
+}
+
+func (m *MemoryNotificationStore) LoadLastId() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastId, nil
+}
+
+func (m *MemoryNotificationStore) SaveLastId(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastId = id
+	return nil
+}
+
+type FileNotificationStore struct {
+	Path string
+}
+
+func (f FileNotificationStore) LoadLastId() (int, error) {
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
+
+func (f FileNotificationStore) SaveLastId(id int) error {
+	return ioutil.WriteFile(f.Path, []byte(strconv.Itoa(id)+"\n"), 0644)
+}
+
+type NotificationWatcherOption struct {
+	Interval    time.Duration // default: 1 minute
+	MaxInterval time.Duration // upper bound of the backoff after errors, default: 10 * Interval
+	SenderId    int
+	Store       NotificationStore // default: MemoryNotificationStore
+	Handler     func(Notification) error
+	OnError     func(error)
+	MarkAsRead  bool // call ReadNotification after Handler succeeds
+	FromStart   bool // deliver notifications that existed before the watcher started
+}
+
+// NotificationWatcher polls for new notifications. They are passed to Handler when one is set,
+// otherwise they are sent on the channel returned by Notifications.
+type NotificationWatcher struct {
+	s             *Service
+	option        NotificationWatcherOption
+	notifications chan Notification
+}
+
+func (s *Service) NewNotificationWatcher(option NotificationWatcherOption) *NotificationWatcher {
+	if option.Interval <= 0 {
+		option.Interval = time.Minute
+	}
+	if option.MaxInterval < option.Interval {
+		option.MaxInterval = 10 * option.Interval
+	}
+	if option.Store == nil {
+		option.Store = &MemoryNotificationStore{}
+	}
+
+	return &NotificationWatcher{
+		s:             s,
+		option:        option,
+		notifications: make(chan Notification),
+	}
+}
+
+func (w *NotificationWatcher) Notifications() <-chan Notification {
+	return w.notifications
+}
+
+// Run polls until ctx is cancelled. The notifications channel is closed when Run returns.
+func (w *NotificationWatcher) Run(ctx context.Context) error {
+	defer close(w.notifications)
+
+	lastId, err := w.option.Store.LoadLastId()
+	if err != nil {
+		return err
+	}
+
+	started := lastId != 0 || w.option.FromStart
+	interval := w.option.Interval
+	for {
+		if !started {
+			lastId, err = w.latestId()
+			started = err == nil
+		} else {
+			lastId, err = w.poll(ctx, lastId)
+		}
+
+		if err != nil {
+			if w.option.OnError != nil {
+				w.option.OnError(err)
+			}
+			interval *= 2
+			if interval > w.option.MaxInterval {
+				interval = w.option.MaxInterval
+			}
+		} else {
+			interval = w.option.Interval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (w *NotificationWatcher) latestId() (int, error) {
+	notifications, err := w.s.GetNotification(GetNotificationQuery{Count: 1, Order: "desc"})
+	if err != nil || len(notifications) == 0 {
+		return 0, err
+	}
+
+	lastId := notifications[0].ID
+	return lastId, w.option.Store.SaveLastId(lastId)
+}
+
+// poll delivers everything after lastId and returns the new highest handled ID.
+func (w *NotificationWatcher) poll(ctx context.Context, lastId int) (int, error) {
+	it := w.s.NewNotificationIterator(GetNotificationQuery{
+		MinId:    lastId,
+		Order:    "asc",
+		SenderId: w.option.SenderId,
+	})
+
+	for it.Next() {
+		notification := it.Notification()
+
+		if w.option.Handler != nil {
+			err := w.option.Handler(notification)
+			if err != nil {
+				return lastId, err
+			}
+		} else {
+			select {
+			case w.notifications <- notification:
+			case <-ctx.Done():
+				return lastId, nil
+			}
+		}
+
+		if w.option.MarkAsRead {
+			_, err := w.s.ReadNotification(notification.ID)
+			if err != nil {
+				return lastId, err
+			}
+		}
+
+		lastId = notification.ID
+		err := w.option.Store.SaveLastId(lastId)
+		if err != nil {
+			return lastId, err
+		}
+	}
+
+	return lastId, it.Err()
+}
@@ -20,6 +20,25 @@ type Project struct {
 	DisplayOrder                      int    `json:"displayOrder"`
 }
 
+type Version struct {
+	ID             int    `json:"id"`
+	ProjectID      int    `json:"projectId"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	StartDate      string `json:"startDate"`
+	ReleaseDueDate string `json:"releaseDueDate"`
+	Archived       bool   `json:"archived"`
+	DisplayOrder   int    `json:"displayOrder"`
+}
+
+type Status struct {
+	ID           int    `json:"id"`
+	ProjectID    int    `json:"projectId"`
+	Name         string `json:"name"`
+	Color        string `json:"color"`
+	DisplayOrder int    `json:"displayOrder"`
+}
+
 func (s *Service) GetProjectList() ([]Project, error) {
 	requestUrl := s.BaseUrl + "/api/v2/projects"
 	urlParams := url.Values{}
@@ -44,3 +63,55 @@ func (s *Service) GetProjectList() ([]Project, error) {
 
 	return projects, nil
 }
+
+// GetVersionList returns the versions (milestones) of a project.
+func (s *Service) GetVersionList(projectIdOrKey string) ([]Version, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/versions"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	err = json.Unmarshal(body, &versions)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return versions, nil
+}
+
+// GetStatusList returns the statuses of a project, including renamed and custom ones.
+func (s *Service) GetStatusList(projectIdOrKey string) ([]Status, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/statuses"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	err = json.Unmarshal(body, &statuses)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,254 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+type GetSharedFileListQuery struct {
+	Order  string // asc, desc (default)
+	Offset int
+	Count  int // default: 1000, max: 1000
+}
+
+func (s *Service) GetSharedFileList(projectIdOrKey string, path string, query GetSharedFileListQuery) ([]SharedFile, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/files/metadata/" + escapeSharedFilePath(path)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+	if query.Offset != 0 {
+		urlParams.Add("offset", strconv.Itoa(query.Offset))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedFiles []SharedFile
+	err = json.Unmarshal(body, &sharedFiles)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return sharedFiles, nil
+}
+
+func (s *Service) GetSharedFile(projectIdOrKey string, sharedFileId int) (io.ReadCloser, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/files/" + strconv.Itoa(sharedFileId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return res.Body, nil
+}
+
+func (s *Service) GetListOfLinkedSharedFiles(issueIdOrKey string) ([]SharedFile, error) {
+	requestUrl := s.BaseUrl + "/api/v2/issues/" + issueIdOrKey + "/sharedFiles"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedFiles []SharedFile
+	err = json.Unmarshal(body, &sharedFiles)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return sharedFiles, nil
+}
+
+func (s *Service) LinkSharedFilesToIssue(issueIdOrKey string, fileIds []int) ([]SharedFile, error) {
+	requestUrl := s.BaseUrl + "/api/v2/issues/" + issueIdOrKey + "/sharedFiles"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	for _, fileId := range fileIds {
+		requestParams.Add("fileId[]", strconv.Itoa(fileId))
+	}
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sharedFiles []SharedFile
+	err = json.Unmarshal(body, &sharedFiles)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return sharedFiles, nil
+}
+
+func (s *Service) RemoveLinkToSharedFileFromIssue(issueIdOrKey string, sharedFileId int) (SharedFile, error) {
+	requestUrl := s.BaseUrl + "/api/v2/issues/" + issueIdOrKey + "/sharedFiles/" + strconv.Itoa(sharedFileId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var sharedFile SharedFile
+
+	req, err := http.NewRequest(http.MethodDelete, requestUrl+"?"+urlParams.Encode(), nil)
+	if err != nil {
+		return sharedFile, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return sharedFile, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return sharedFile, err
+	}
+
+	err = json.Unmarshal(body, &sharedFile)
+	if err != nil {
+		return sharedFile, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return sharedFile, nil
+}
+
+// WalkSharedFiles calls fn for every file and directory below path, parents before their contents.
+func (s *Service) WalkSharedFiles(projectIdOrKey string, path string, fn func(sharedFile SharedFile) error) error {
+	const count = 1000
+
+	for offset := 0; ; offset += count {
+		sharedFiles, err := s.GetSharedFileList(projectIdOrKey, path, GetSharedFileListQuery{Order: "asc", Offset: offset, Count: count})
+		if err != nil {
+			return err
+		}
+
+		for _, sharedFile := range sharedFiles {
+			err = fn(sharedFile)
+			if err != nil {
+				return err
+			}
+			if sharedFile.Type == "directory" {
+				err = s.WalkSharedFiles(projectIdOrKey, strings.TrimSuffix(sharedFile.Dir, "/")+"/"+sharedFile.Name, fn)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(sharedFiles) < count {
+			return nil
+		}
+	}
+}
+
+// MirrorSharedFiles copies the project's file tree below path into dir. Files whose size and
+// modification time already match are not downloaded again.
+func (s *Service) MirrorSharedFiles(projectIdOrKey string, path string, dir string) error {
+	return s.WalkSharedFiles(projectIdOrKey, path, func(sharedFile SharedFile) error {
+		var segments []string
+		for _, segment := range splitWikiPath(sharedFile.Dir + "/" + sharedFile.Name) {
+			segments = append(segments, sanitizeFileName(segment))
+		}
+		local := filepath.Join(append([]string{dir}, segments...)...)
+
+		if sharedFile.Type == "directory" {
+			return os.MkdirAll(local, 0755)
+		}
+
+		info, err := os.Stat(local)
+		if err == nil && info.Size() == int64(sharedFile.Size) && info.ModTime().Equal(sharedFile.Updated) {
+			return nil
+		}
+
+		err = s.downloadSharedFile(projectIdOrKey, sharedFile.ID, local)
+		if err != nil {
+			return err
+		}
+
+		return os.Chtimes(local, sharedFile.Updated, sharedFile.Updated)
+	})
+}
+
+func (s *Service) downloadSharedFile(projectIdOrKey string, sharedFileId int, local string) error {
+	err := os.MkdirAll(filepath.Dir(local), 0755)
+	if err != nil {
+		return err
+	}
+
+	body, err := s.GetSharedFile(projectIdOrKey, sharedFileId)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	file, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, body)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func escapeSharedFilePath(path string) string {
+	segments := splitWikiPath(path)
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -1,11 +1,17 @@
 package backlog
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"image"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 )
@@ -23,11 +29,11 @@ type Space struct {
 }
 
 type GetRecentUpdatesQuery struct {
-	ActivityTypeId []int
-	minId          int
-	maxId          int
-	count          int
-	order          string
+	ActivityTypeId []ActivityType
+	MinId          int
+	MaxId          int
+	Count          int    // default: 20, max: 100
+	Order          string // asc, desc (default)
 }
 
 type Changes struct {
@@ -40,26 +46,118 @@ type Changes struct {
 type Content struct {
 	ID          int    `json:"id"`
 	KeyID       int    `json:"key_id"`
+	Number      int    `json:"number"` // pull request activities
 	Summary     string `json:"summary"`
 	Description string `json:"description"`
 	Comment     struct {
 		ID      int    `json:"id"`
 		Content string `json:"content"`
 	} `json:"comment"`
-	Changes []Changes `json:"changes"`
+	Changes    []Changes   `json:"changes"`
+	Repository *Repository `json:"repository"` // pull request activities
+}
+
+type ActivityType int
+
+const (
+	ActivityTypeIssueCreated             ActivityType = 1
+	ActivityTypeIssueUpdated             ActivityType = 2
+	ActivityTypeIssueCommented           ActivityType = 3
+	ActivityTypeIssueDeleted             ActivityType = 4
+	ActivityTypeWikiCreated              ActivityType = 5
+	ActivityTypeWikiUpdated              ActivityType = 6
+	ActivityTypeWikiDeleted              ActivityType = 7
+	ActivityTypeFileAdded                ActivityType = 8
+	ActivityTypeFileUpdated              ActivityType = 9
+	ActivityTypeFileDeleted              ActivityType = 10
+	ActivityTypeSvnCommitted             ActivityType = 11
+	ActivityTypeGitPushed                ActivityType = 12
+	ActivityTypeGitRepositoryCreated     ActivityType = 13
+	ActivityTypeIssueMultiUpdated        ActivityType = 14
+	ActivityTypeProjectUserAdded         ActivityType = 15
+	ActivityTypeProjectUserRemoved       ActivityType = 16
+	ActivityTypeCommentNotificationAdded ActivityType = 17
+	ActivityTypePullRequestAdded         ActivityType = 18
+	ActivityTypePullRequestUpdated       ActivityType = 19
+	ActivityTypePullRequestCommented     ActivityType = 20
+	ActivityTypePullRequestDeleted       ActivityType = 21
+	ActivityTypeMilestoneCreated         ActivityType = 22
+	ActivityTypeMilestoneUpdated         ActivityType = 23
+	ActivityTypeMilestoneDeleted         ActivityType = 24
+	ActivityTypeProjectGroupAdded        ActivityType = 25
+	ActivityTypeProjectGroupDeleted      ActivityType = 26
+)
+
+func (t ActivityType) String() string {
+	switch t {
+	case ActivityTypeIssueCreated:
+		return "IssueCreated"
+	case ActivityTypeIssueUpdated:
+		return "IssueUpdated"
+	case ActivityTypeIssueCommented:
+		return "IssueCommented"
+	case ActivityTypeIssueDeleted:
+		return "IssueDeleted"
+	case ActivityTypeWikiCreated:
+		return "WikiCreated"
+	case ActivityTypeWikiUpdated:
+		return "WikiUpdated"
+	case ActivityTypeWikiDeleted:
+		return "WikiDeleted"
+	case ActivityTypeFileAdded:
+		return "FileAdded"
+	case ActivityTypeFileUpdated:
+		return "FileUpdated"
+	case ActivityTypeFileDeleted:
+		return "FileDeleted"
+	case ActivityTypeSvnCommitted:
+		return "SvnCommitted"
+	case ActivityTypeGitPushed:
+		return "GitPushed"
+	case ActivityTypeGitRepositoryCreated:
+		return "GitRepositoryCreated"
+	case ActivityTypeIssueMultiUpdated:
+		return "IssueMultiUpdated"
+	case ActivityTypeProjectUserAdded:
+		return "ProjectUserAdded"
+	case ActivityTypeProjectUserRemoved:
+		return "ProjectUserRemoved"
+	case ActivityTypeCommentNotificationAdded:
+		return "CommentNotificationAdded"
+	case ActivityTypePullRequestAdded:
+		return "PullRequestAdded"
+	case ActivityTypePullRequestUpdated:
+		return "PullRequestUpdated"
+	case ActivityTypePullRequestCommented:
+		return "PullRequestCommented"
+	case ActivityTypePullRequestDeleted:
+		return "PullRequestDeleted"
+	case ActivityTypeMilestoneCreated:
+		return "MilestoneCreated"
+	case ActivityTypeMilestoneUpdated:
+		return "MilestoneUpdated"
+	case ActivityTypeMilestoneDeleted:
+		return "MilestoneDeleted"
+	case ActivityTypeProjectGroupAdded:
+		return "ProjectGroupAdded"
+	case ActivityTypeProjectGroupDeleted:
+		return "ProjectGroupDeleted"
+	default:
+		return "ActivityType(" + strconv.Itoa(int(t)) + ")"
+	}
 }
 
 type RecentUpdate struct {
-	ID            int     `json:"id"`
-	Project       Project `json:"project"`
-	Type          int     `json:"type"`
-	Content       Content `json:"content"`
+	ID            int          `json:"id"`
+	Project       Project      `json:"project"`
+	Type          ActivityType `json:"type"`
+	Content       Content      `json:"content"`
 	Notifications []struct {
-		ID                  int  `json:"id"`
-		AlreadyRead         bool `json:"alreadyRead"`
-		Reason              int  `json:"reason"`
-		User                User `json:"user"`
-		ResourceAlreadyRead bool `json:"resourceAlreadyRead"`
+		ID                  int                `json:"id"`
+		AlreadyRead         bool               `json:"alreadyRead"`
+		Reason              NotificationReason `json:"reason"`
+		User                User               `json:"user"`
+		ResourceAlreadyRead bool               `json:"resourceAlreadyRead"`
 	} `json:"notifications"`
 	CreatedUser User      `json:"createdUser"`
 	Created     time.Time `json:"created"`
@@ -70,6 +168,27 @@ type SpaceNotification struct {
 	Updated time.Time `json:"updated"`
 }
 
+type DiskUsage struct {
+	Capacity   int64              `json:"capacity"`
+	Issue      int64              `json:"issue"`
+	Wiki       int64              `json:"wiki"`
+	File       int64              `json:"file"`
+	Subversion int64              `json:"subversion"`
+	Git        int64              `json:"git"`
+	GitLFS     int64              `json:"gitLFS"`
+	Details    []ProjectDiskUsage `json:"details"`
+}
+
+type ProjectDiskUsage struct {
+	ProjectID  int   `json:"projectId"`
+	Issue      int64 `json:"issue"`
+	Wiki       int64 `json:"wiki"`
+	File       int64 `json:"file"`
+	Subversion int64 `json:"subversion"`
+	Git        int64 `json:"git"`
+	GitLFS     int64 `json:"gitLFS"`
+}
+
 func (s *Service) GetSpace() (Space, error) {
 	requestUrl := s.BaseUrl + "/api/v2/space"
 	urlParams := url.Values{}
@@ -100,13 +219,7 @@ func (s *Service) GetRecentUpdates(query GetRecentUpdatesQuery) ([]RecentUpdate,
 	requestUrl := s.BaseUrl + "/api/v2/space/activities"
 	urlParams := url.Values{}
 	urlParams.Add("apiKey", s.Config.ApiKey)
-	for _, typeId := range query.ActivityTypeId {
-		urlParams.Add("activityTypeId[]", strconv.Itoa(typeId))
-	}
-	urlParams.Add("minId", strconv.Itoa(query.minId))
-	urlParams.Add("maxId", strconv.Itoa(query.maxId))
-	urlParams.Add("count", strconv.Itoa(query.count))
-	urlParams.Add("order", query.order)
+	addRecentUpdatesParams(urlParams, query)
 
 	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
 	if err != nil {
@@ -128,7 +241,42 @@ func (s *Service) GetRecentUpdates(query GetRecentUpdatesQuery) ([]RecentUpdate,
 	return recentUpdates, nil
 }
 
-// func (s *Service) GetSpaceLogo() (image, error) {}
+func addRecentUpdatesParams(urlParams url.Values, query GetRecentUpdatesQuery) {
+	for _, typeId := range query.ActivityTypeId {
+		urlParams.Add("activityTypeId[]", strconv.Itoa(int(typeId)))
+	}
+	if query.MinId != 0 {
+		urlParams.Add("minId", strconv.Itoa(query.MinId))
+	}
+	if query.MaxId != 0 {
+		urlParams.Add("maxId", strconv.Itoa(query.MaxId))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+}
+
+func (s *Service) GetSpaceLogo() (image.Image, error) {
+	requestUrl := s.BaseUrl + "/api/v2/space/image"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	img, _, err := image.Decode(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
 
 func (s *Service) GetSpaceNotification() (SpaceNotification, error) {
 	requestUrl := s.BaseUrl + "/api/v2/space/notification"
@@ -156,6 +304,104 @@ func (s *Service) GetSpaceNotification() (SpaceNotification, error) {
 	return spaceNotification, nil
 }
 
-// func (s *Service) UpdateSpaceNotification() (string, error) {}
-// func (s *Service) GutSpaceDiskUsage() (string, error) {}
-// func (s *Service) PostAttachmentFile(file *File) (string, error) {}
+func (s *Service) UpdateSpaceNotification(content string) (SpaceNotification, error) {
+	requestUrl := s.BaseUrl + "/api/v2/space/notification"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("content", content)
+
+	var spaceNotification SpaceNotification
+
+	req, err := http.NewRequest(http.MethodPut, requestUrl+"?"+urlParams.Encode(), strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return spaceNotification, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return spaceNotification, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return spaceNotification, err
+	}
+
+	err = json.Unmarshal(body, &spaceNotification)
+	if err != nil {
+		return spaceNotification, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return spaceNotification, nil
+}
+
+func (s *Service) GetSpaceDiskUsage() (DiskUsage, error) {
+	requestUrl := s.BaseUrl + "/api/v2/space/diskUsage"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var diskUsage DiskUsage
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return diskUsage, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return diskUsage, err
+	}
+
+	err = json.Unmarshal(body, &diskUsage)
+	if err != nil {
+		return diskUsage, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return diskUsage, nil
+}
+
+func (s *Service) PostAttachmentFile(name string, file io.Reader) (Attachment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/space/attachment"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var attachment Attachment
+
+	var requestBody bytes.Buffer
+	writer := multipart.NewWriter(&requestBody)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return attachment, err
+	}
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return attachment, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return attachment, err
+	}
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), writer.FormDataContentType(), &requestBody)
+	if err != nil {
+		return attachment, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return attachment, err
+	}
+
+	err = json.Unmarshal(body, &attachment)
+	if err != nil {
+		return attachment, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return attachment, nil
+}
@@ -0,0 +1,187 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+type Star struct {
+	ID        int       `json:"id"`
+	Comment   string    `json:"comment"`
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Presenter User      `json:"presenter"`
+	Created   time.Time `json:"created"`
+}
+
+type GetReceivedStarListQuery struct {
+	MinId int
+	MaxId int
+	Count int    // default: 20, max: 100
+	Order string // asc, desc (default)
+}
+
+type CountUserReceivedStarsQuery struct {
+	Since string // yyyy-MM-dd
+	Until string // yyyy-MM-dd
+}
+
+type MonthlyStarCount struct {
+	User  User
+	Month time.Time // first day of the month
+	Count int
+}
+
+func (s *Service) AddStarToIssue(issueId int) (bool, error) {
+	return s.addStar("issueId", issueId)
+}
+
+func (s *Service) AddStarToComment(commentId int) (bool, error) {
+	return s.addStar("commentId", commentId)
+}
+
+func (s *Service) AddStarToWiki(wikiId int) (bool, error) {
+	return s.addStar("wikiId", wikiId)
+}
+
+func (s *Service) AddStarToPullRequestComment(pullRequestCommentId int) (bool, error) {
+	return s.addStar("pullRequestCommentId", pullRequestCommentId)
+}
+
+func (s *Service) addStar(target string, id int) (bool, error) {
+	requestUrl := s.BaseUrl + "/api/v2/stars"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add(target, strconv.Itoa(id))
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return false, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return false, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return false, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return true, nil
+}
+
+func (s *Service) GetReceivedStarList(userId int, query GetReceivedStarListQuery) ([]Star, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/" + strconv.Itoa(userId) + "/stars"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.MinId != 0 {
+		urlParams.Add("minId", strconv.Itoa(query.MinId))
+	}
+	if query.MaxId != 0 {
+		urlParams.Add("maxId", strconv.Itoa(query.MaxId))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var stars []Star
+	err = json.Unmarshal(body, &stars)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return stars, nil
+}
+
+func (s *Service) CountUserReceivedStars(userId int, query CountUserReceivedStarsQuery) (int, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/" + strconv.Itoa(userId) + "/stars/count"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.Since != "" {
+		urlParams.Add("since", query.Since)
+	}
+	if query.Until != "" {
+		urlParams.Add("until", query.Until)
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var count struct {
+		Count int
+	}
+	err = json.Unmarshal(body, &count)
+	if err != nil {
+		return 0, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return count.Count, nil
+}
+
+// CountReceivedStarsPerMonth counts the stars each user received in every calendar month between since and until.
+func (s *Service) CountReceivedStarsPerMonth(users []User, since time.Time, until time.Time) ([]MonthlyStarCount, error) {
+	if until.Before(since) {
+		return nil, errors.New("until is before since")
+	}
+
+	var counts []MonthlyStarCount
+	for _, user := range users {
+		month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, since.Location())
+		for !month.After(until) {
+			from := month
+			if from.Before(since) {
+				from = since
+			}
+			to := month.AddDate(0, 1, -1)
+			if to.After(until) {
+				to = until
+			}
+
+			count, err := s.CountUserReceivedStars(user.ID, CountUserReceivedStarsQuery{
+				Since: from.Format("2006-01-02"),
+				Until: to.Format("2006-01-02"),
+			})
+			if err != nil {
+				return counts, err
+			}
+			counts = append(counts, MonthlyStarCount{User: user, Month: month, Count: count})
+
+			month = month.AddDate(0, 1, 0)
+		}
+	}
+
+	return counts, nil
+}
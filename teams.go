@@ -0,0 +1,306 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+type GetTeamListQuery struct {
+	Order  string // asc, desc (default)
+	Offset int
+	Count  int // default: 20, max: 100
+}
+
+type Team struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	Members      []User    `json:"members"`
+	DisplayOrder int       `json:"displayOrder"`
+	CreatedUser  User      `json:"createdUser"`
+	Created      time.Time `json:"created"`
+	UpdatedUser  User      `json:"updatedUser"`
+	Updated      time.Time `json:"updated"`
+}
+
+type TeamParams struct {
+	Name string
+	// Members are user IDs. UpdateTeam leaves the members unchanged when Members is empty,
+	// so it can't remove the last members of a team; delete and re-add the team instead.
+	Members []int
+}
+
+func (s *Service) GetTeamList(query GetTeamListQuery) ([]Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/teams"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+	if query.Offset != 0 {
+		urlParams.Add("offset", strconv.Itoa(query.Offset))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []Team
+	err = json.Unmarshal(body, &teams)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return teams, nil
+}
+
+func (s *Service) GetTeam(teamId int) (Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/teams/" + strconv.Itoa(teamId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var team Team
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return team, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return team, err
+	}
+
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return team, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return team, nil
+}
+
+func (s *Service) AddTeam(params TeamParams) (Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/teams"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("name", params.Name)
+	for _, member := range params.Members {
+		requestParams.Add("members[]", strconv.Itoa(member))
+	}
+
+	var team Team
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return team, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return team, err
+	}
+
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return team, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return team, nil
+}
+
+func (s *Service) UpdateTeam(teamId int, params TeamParams) (Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/teams/" + strconv.Itoa(teamId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	if params.Name != "" {
+		requestParams.Add("name", params.Name)
+	}
+	for _, member := range params.Members {
+		requestParams.Add("members[]", strconv.Itoa(member))
+	}
+
+	var team Team
+
+	req, err := http.NewRequest(http.MethodPatch, requestUrl+"?"+urlParams.Encode(), strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return team, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return team, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return team, err
+	}
+
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return team, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return team, nil
+}
+
+func (s *Service) DeleteTeam(teamId int) (Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/teams/" + strconv.Itoa(teamId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var team Team
+
+	req, err := http.NewRequest(http.MethodDelete, requestUrl+"?"+urlParams.Encode(), nil)
+	if err != nil {
+		return team, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return team, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return team, err
+	}
+
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return team, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return team, nil
+}
+
+func (s *Service) GetTeamIcon(teamId int) (image.Image, error) {
+	requestUrl := s.BaseUrl + "/api/v2/teams/" + strconv.Itoa(teamId) + "/icon"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	img, _, err := image.Decode(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func (s *Service) GetProjectTeamList(projectIdOrKey string) ([]Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/teams"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []Team
+	err = json.Unmarshal(body, &teams)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return teams, nil
+}
+
+func (s *Service) AddProjectTeam(projectIdOrKey string, teamId int) (Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/teams"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("teamId", strconv.Itoa(teamId))
+
+	var team Team
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return team, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return team, err
+	}
+
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return team, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return team, nil
+}
+
+func (s *Service) DeleteProjectTeam(projectIdOrKey string, teamId int) (Team, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/teams"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	urlParams.Add("teamId", strconv.Itoa(teamId))
+
+	var team Team
+
+	req, err := http.NewRequest(http.MethodDelete, requestUrl+"?"+urlParams.Encode(), nil)
+	if err != nil {
+		return team, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return team, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return team, err
+	}
+
+	err = json.Unmarshal(body, &team)
+	if err != nil {
+		return team, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return team, nil
+}
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 )
 
@@ -25,6 +26,27 @@ type User struct {
 	MailAddress string `json:"mailAddress"`
 }
 
+type RecentlyViewedQuery struct {
+	Order  string // asc, desc (default)
+	Offset int
+	Count  int // default: 20, max: 100
+}
+
+type RecentlyViewedIssue struct {
+	Issue   Issue     `json:"issue"`
+	Updated time.Time `json:"updated"`
+}
+
+type RecentlyViewedProject struct {
+	Project Project   `json:"project"`
+	Updated time.Time `json:"updated"`
+}
+
+type RecentlyViewedWiki struct {
+	Page    WikiListItem `json:"page"`
+	Updated time.Time    `json:"updated"`
+}
+
 func (s *Service) GetUserList() ([]User, error) {
 	requestUrl := s.BaseUrl + "/api/v2/users"
 	urlParams := url.Values{}
@@ -223,9 +245,118 @@ func (s *Service) GetUserIcon(userId int) (image.Image, error) {
 	return img, nil
 }
 
-// func (s *Service) GetUserRecentUpdates(userId int, query QueryActivities) (string, error) {}
-// func (s *Service) GetReceivedStarList(userId int, query QueryStar) (string, error) {}
-// func (s *Service) CountUserReceivedStars(query QueryStarsCount) (string, error) {}
-// func (s *Service) GetListOfRecentlyViewedIssues(query RecentlyViewed) (string, error) {}
-// func (s *Service) GetListOfRecentlyViewedProjects(query RecentlyViewed) (string, error) {}
-// func (s *Service) GetListOfRecentlyViewedWikis(query RecentlyViewed) (string, error) {}
+func (s *Service) GetUserRecentUpdates(userId int, query GetRecentUpdatesQuery) ([]RecentUpdate, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/" + strconv.Itoa(userId) + "/activities"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	addRecentUpdatesParams(urlParams, query)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var recentUpdates []RecentUpdate
+	err = json.Unmarshal(body, &recentUpdates)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return recentUpdates, nil
+}
+
+func (s *Service) GetListOfRecentlyViewedIssues(query RecentlyViewedQuery) ([]RecentlyViewedIssue, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/myself/recentlyViewedIssues"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	addRecentlyViewedParams(urlParams, query)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []RecentlyViewedIssue
+	err = json.Unmarshal(body, &issues)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return issues, nil
+}
+
+func (s *Service) GetListOfRecentlyViewedProjects(query RecentlyViewedQuery) ([]RecentlyViewedProject, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/myself/recentlyViewedProjects"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	addRecentlyViewedParams(urlParams, query)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []RecentlyViewedProject
+	err = json.Unmarshal(body, &projects)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return projects, nil
+}
+
+func (s *Service) GetListOfRecentlyViewedWikis(query RecentlyViewedQuery) ([]RecentlyViewedWiki, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/myself/recentlyViewedWikis"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	addRecentlyViewedParams(urlParams, query)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var wikis []RecentlyViewedWiki
+	err = json.Unmarshal(body, &wikis)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return wikis, nil
+}
+
+func addRecentlyViewedParams(urlParams url.Values, query RecentlyViewedQuery) {
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+	if query.Offset != 0 {
+		urlParams.Add("offset", strconv.Itoa(query.Offset))
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+}
@@ -0,0 +1,255 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+type GetWatchingListQuery struct {
+	Order               string // asc, desc (default)
+	Sort                string // created, updated, issueUpdated (default)
+	Count               int    // default: 20, max: 100
+	Offset              int
+	ResourceAlreadyRead *bool // nil: not filtered
+	IssueId             []int
+}
+
+type CountWatchingQuery struct {
+	ResourceAlreadyRead *bool // nil: not filtered
+	AlreadyRead         *bool // nil: not filtered
+}
+
+type Watching struct {
+	ID                  int        `json:"id"`
+	ResourceAlreadyRead bool       `json:"resourceAlreadyRead"`
+	Note                string     `json:"note"`
+	Type                string     `json:"type"`
+	Issue               Issue      `json:"issue"`
+	LastContentUpdated  *time.Time `json:"lastContentUpdated"`
+	Created             time.Time  `json:"created"`
+	Updated             time.Time  `json:"updated"`
+}
+
+func (s *Service) GetWatchingList(userId int, query GetWatchingListQuery) ([]Watching, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/" + strconv.Itoa(userId) + "/watchings"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.Order != "" {
+		urlParams.Add("order", query.Order)
+	}
+	if query.Sort != "" {
+		urlParams.Add("sort", query.Sort)
+	}
+	if query.Count != 0 {
+		urlParams.Add("count", strconv.Itoa(query.Count))
+	}
+	if query.Offset != 0 {
+		urlParams.Add("offset", strconv.Itoa(query.Offset))
+	}
+	if query.ResourceAlreadyRead != nil {
+		urlParams.Add("resourceAlreadyRead", strconv.FormatBool(*query.ResourceAlreadyRead))
+	}
+	for _, issueId := range query.IssueId {
+		urlParams.Add("issueId[]", strconv.Itoa(issueId))
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var watchings []Watching
+	err = json.Unmarshal(body, &watchings)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return watchings, nil
+}
+
+func (s *Service) CountWatching(userId int, query CountWatchingQuery) (int, error) {
+	requestUrl := s.BaseUrl + "/api/v2/users/" + strconv.Itoa(userId) + "/watchings/count"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+	if query.ResourceAlreadyRead != nil {
+		urlParams.Add("resourceAlreadyRead", strconv.FormatBool(*query.ResourceAlreadyRead))
+	}
+	if query.AlreadyRead != nil {
+		urlParams.Add("alreadyRead", strconv.FormatBool(*query.AlreadyRead))
+	}
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var count struct {
+		Count int
+	}
+	err = json.Unmarshal(body, &count)
+	if err != nil {
+		return 0, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return count.Count, nil
+}
+
+func (s *Service) GetWatching(watchingId int) (Watching, error) {
+	requestUrl := s.BaseUrl + "/api/v2/watchings/" + strconv.Itoa(watchingId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var watching Watching
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return watching, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return watching, err
+	}
+
+	err = json.Unmarshal(body, &watching)
+	if err != nil {
+		return watching, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return watching, nil
+}
+
+func (s *Service) AddWatching(issueIdOrKey string, note string) (Watching, error) {
+	requestUrl := s.BaseUrl + "/api/v2/watchings"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("issueIdOrKey", issueIdOrKey)
+	requestParams.Add("note", note)
+
+	var watching Watching
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return watching, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return watching, err
+	}
+
+	err = json.Unmarshal(body, &watching)
+	if err != nil {
+		return watching, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return watching, nil
+}
+
+func (s *Service) UpdateWatching(watchingId int, note string) (Watching, error) {
+	requestUrl := s.BaseUrl + "/api/v2/watchings/" + strconv.Itoa(watchingId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	requestParams.Add("note", note)
+
+	var watching Watching
+
+	req, err := http.NewRequest(http.MethodPatch, requestUrl+"?"+urlParams.Encode(), strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return watching, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return watching, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return watching, err
+	}
+
+	err = json.Unmarshal(body, &watching)
+	if err != nil {
+		return watching, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return watching, nil
+}
+
+func (s *Service) DeleteWatching(watchingId int) (Watching, error) {
+	requestUrl := s.BaseUrl + "/api/v2/watchings/" + strconv.Itoa(watchingId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var watching Watching
+
+	req, err := http.NewRequest(http.MethodDelete, requestUrl+"?"+urlParams.Encode(), nil)
+	if err != nil {
+		return watching, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return watching, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return watching, err
+	}
+
+	err = json.Unmarshal(body, &watching)
+	if err != nil {
+		return watching, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return watching, nil
+}
+
+func (s *Service) ReadWatching(watchingId int) (bool, error) {
+	requestUrl := s.BaseUrl + "/api/v2/watchings/" + strconv.Itoa(watchingId) + "/markAsRead"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/json", nil)
+	if err != nil {
+		return false, err
+	}
+
+	defer res.Body.Close()
+	_, err = ioutil.ReadAll(res.Body)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
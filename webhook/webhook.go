@@ -0,0 +1,231 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ksmt88/go-backlog"
+)
+
+const defaultMaxBodySize = 1 << 20
+
+type HandlerFunc func(activity backlog.RecentUpdate, r *http.Request) error
+
+// Handler receives Backlog webhook requests and dispatches each activity by its type.
+type Handler struct {
+	MaxBodySize       int64 // default: 1MB
+	TrustForwardedFor bool  // check the client address from X-Forwarded-For, see TrustProxies
+	OnError           func(err error, r *http.Request)
+
+	mu       sync.RWMutex
+	handlers map[backlog.ActivityType][]HandlerFunc
+	fallback []HandlerFunc
+	allowed  []*net.IPNet
+	proxies  []*net.IPNet
+}
+
+func NewHandler() *Handler {
+	return &Handler{
+		handlers: map[backlog.ActivityType][]HandlerFunc{},
+	}
+}
+
+func (h *Handler) On(activityType backlog.ActivityType, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[activityType] = append(h.handlers[activityType], fn)
+}
+
+// OnOther registers fn for activity types that have no handler of their own.
+func (h *Handler) OnOther(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = append(h.fallback, fn)
+}
+
+// AllowIPs restricts requests to the given addresses or CIDR ranges. All sources are accepted until it is called.
+func (h *Handler) AllowIPs(addresses ...string) error {
+	allowed, err := parseIPNets(addresses)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.allowed = append(h.allowed, allowed...)
+	return nil
+}
+
+// TrustProxies sets the addresses or CIDR ranges of the proxies in front of the handler. With TrustForwardedFor,
+// the client is the rightmost X-Forwarded-For address that is not a trusted proxy. Without trusted proxies,
+// the direct peer is taken to be the only proxy and the rightmost address is used.
+func (h *Handler) TrustProxies(addresses ...string) error {
+	proxies, err := parseIPNets(addresses)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.proxies = append(h.proxies, proxies...)
+	return nil
+}
+
+func parseIPNets(addresses []string) ([]*net.IPNet, error) {
+	var ipNets []*net.IPNet
+	for _, address := range addresses {
+		if !strings.Contains(address, "/") {
+			if ip := net.ParseIP(address); ip != nil && ip.To4() != nil {
+				address += "/32"
+			} else {
+				address += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(address)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.isAllowed(r) {
+		h.error(errors.New("webhook source not allowed: "+r.RemoteAddr), r)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	maxBodySize := h.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	activity, err := Parse(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		h.error(err, r)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	err = h.Dispatch(activity, r)
+	if err != nil {
+		h.error(err, r)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Dispatch calls the handlers registered for the activity's type, stopping at the first error.
+func (h *Handler) Dispatch(activity backlog.RecentUpdate, r *http.Request) error {
+	h.mu.RLock()
+	handlers, ok := h.handlers[activity.Type]
+	if !ok {
+		handlers = h.fallback
+	}
+	h.mu.RUnlock()
+
+	for _, fn := range handlers {
+		err := fn(activity, r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse decodes a webhook body. It can also be used to replay recorded payloads.
+func Parse(body io.Reader) (backlog.RecentUpdate, error) {
+	var activity backlog.RecentUpdate
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return activity, err
+	}
+
+	err = json.Unmarshal(b, &activity)
+	if err != nil {
+		return activity, err
+	}
+	if activity.Type == 0 || activity.Project.ID == 0 {
+		return activity, errors.New("webhook payload is not a Backlog activity")
+	}
+
+	return activity, nil
+}
+
+func (h *Handler) isAllowed(r *http.Request) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.allowed) == 0 {
+		return true
+	}
+
+	ip := h.remoteIP(r)
+	return ip != nil && contains(h.allowed, ip)
+}
+
+// remoteIP must be called with h.mu held. The leftmost X-Forwarded-For entries are set by the client
+// and are only used once every address to their right is a trusted proxy.
+func (h *Handler) remoteIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	peer := net.ParseIP(host)
+
+	forwardedFor := r.Header["X-Forwarded-For"]
+	if !h.TrustForwardedFor || len(forwardedFor) == 0 {
+		return peer
+	}
+
+	var chain []string
+	for _, value := range forwardedFor {
+		chain = append(chain, strings.Split(value, ",")...)
+	}
+	if len(h.proxies) == 0 {
+		return net.ParseIP(strings.TrimSpace(chain[len(chain)-1]))
+	}
+	if !contains(h.proxies, peer) {
+		return peer
+	}
+
+	var ip net.IP
+	for i := len(chain) - 1; i >= 0; i-- {
+		ip = net.ParseIP(strings.TrimSpace(chain[i]))
+		if ip == nil || !contains(h.proxies, ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+func contains(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) error(err error, r *http.Request) {
+	if h.OnError != nil {
+		h.OnError(err, r)
+	}
+}
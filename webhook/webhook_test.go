@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ksmt88/go-backlog"
+)
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func post(h http.Handler, body []byte, remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	if remoteAddr != "" {
+		r.RemoteAddr = remoteAddr
+	}
+	if forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandlerDispatchesIssueCreated(t *testing.T) {
+	h := NewHandler()
+
+	var got backlog.RecentUpdate
+	h.On(backlog.ActivityTypeIssueCreated, func(activity backlog.RecentUpdate, r *http.Request) error {
+		got = activity
+		return nil
+	})
+	h.On(backlog.ActivityTypeIssueUpdated, func(activity backlog.RecentUpdate, r *http.Request) error {
+		t.Error("issue updated handler called for an issue created payload")
+		return nil
+	})
+	h.OnOther(func(activity backlog.RecentUpdate, r *http.Request) error {
+		t.Error("fallback called for an issue created payload")
+		return nil
+	})
+
+	w := post(h, fixture(t, "issue_created.json"), "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got.ID != 3153 || got.Project.ProjectKey != "SUB" || got.Content.KeyID != 121 || got.Content.Summary != "Login fails on Safari" {
+		t.Errorf("unexpected activity: %+v", got)
+	}
+}
+
+func TestHandlerFallback(t *testing.T) {
+	h := NewHandler()
+
+	h.On(backlog.ActivityTypeIssueCreated, func(activity backlog.RecentUpdate, r *http.Request) error {
+		t.Error("issue created handler called for a wiki payload")
+		return nil
+	})
+	var got backlog.ActivityType
+	h.OnOther(func(activity backlog.RecentUpdate, r *http.Request) error {
+		got = activity.Type
+		return nil
+	})
+
+	w := post(h, fixture(t, "wiki_created.json"), "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got != backlog.ActivityTypeWikiCreated {
+		t.Errorf("fallback got type %v, want %v", got, backlog.ActivityTypeWikiCreated)
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"malformed json", http.MethodPost, `{"id": 1,`, http.StatusBadRequest},
+		{"not an activity", http.MethodPost, `{"hello": "world"}`, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs int
+			h := NewHandler()
+			h.OnError = func(err error, r *http.Request) {
+				errs++
+			}
+			h.OnOther(func(activity backlog.RecentUpdate, r *http.Request) error {
+				t.Error("handler called for a rejected request")
+				return nil
+			})
+
+			r := httptest.NewRequest(tt.method, "/webhook", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusBadRequest && errs != 1 {
+				t.Errorf("OnError called %d times, want 1", errs)
+			}
+		})
+	}
+}
+
+func TestHandlerAllowIPs(t *testing.T) {
+	body := fixture(t, "issue_created.json")
+
+	tests := []struct {
+		name              string
+		trustForwardedFor bool
+		proxies           []string
+		remoteAddr        string
+		forwardedFor      string
+		want              int
+	}{
+		{"allowed address", false, nil, "203.0.113.10:4321", "", http.StatusOK},
+		{"allowed range", false, nil, "198.51.100.7:4321", "", http.StatusOK},
+		{"disallowed address", false, nil, "192.0.2.1:4321", "", http.StatusForbidden},
+		{"forwarded for ignored", false, nil, "192.0.2.1:4321", "203.0.113.10", http.StatusForbidden},
+		{"forwarded for does not bypass", false, nil, "203.0.113.10:4321", "192.0.2.1", http.StatusOK},
+		{"forwarded for trusted", true, nil, "10.0.0.1:4321", "203.0.113.10", http.StatusOK},
+		{"forwarded for trusted and disallowed", true, nil, "10.0.0.1:4321", "192.0.2.1", http.StatusForbidden},
+		{"spoofed leftmost address", true, nil, "10.0.0.1:4321", "203.0.113.10, 192.0.2.1", http.StatusForbidden},
+		{"spoofed behind trusted proxies", true, []string{"10.0.0.0/8"}, "10.0.0.1:4321", "203.0.113.10, 192.0.2.1, 10.0.0.2", http.StatusForbidden},
+		{"client behind trusted proxies", true, []string{"10.0.0.0/8"}, "10.0.0.1:4321", "192.0.2.1, 203.0.113.10, 10.0.0.2", http.StatusOK},
+		{"peer is not a trusted proxy", true, []string{"10.0.0.0/8"}, "192.0.2.1:4321", "203.0.113.10", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler()
+			h.TrustForwardedFor = tt.trustForwardedFor
+			err := h.AllowIPs("203.0.113.10", "198.51.100.0/24")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = h.TrustProxies(tt.proxies...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.OnOther(func(activity backlog.RecentUpdate, r *http.Request) error {
+				return nil
+			})
+
+			w := post(h, body, tt.remoteAddr, tt.forwardedFor)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowIPsInvalid(t *testing.T) {
+	err := NewHandler().AllowIPs("not-an-ip")
+	if err == nil {
+		t.Error("AllowIPs(not-an-ip): expected an error")
+	}
+}
@@ -0,0 +1,237 @@
+package backlog
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+type Webhook struct {
+	ID              int            `json:"id"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	HookURL         string         `json:"hookUrl"`
+	AllEvent        bool           `json:"allEvent"`
+	ActivityTypeIds []ActivityType `json:"activityTypeIds"`
+	CreatedUser     User           `json:"createdUser"`
+	Created         time.Time      `json:"created"`
+	UpdatedUser     User           `json:"updatedUser"`
+	Updated         time.Time      `json:"updated"`
+}
+
+type WebhookParams struct {
+	Name            string
+	Description     string
+	HookUrl         string
+	AllEvent        bool
+	ActivityTypeIds []ActivityType // ignored when AllEvent is true
+}
+
+func (s *Service) GetWebhookList(projectIdOrKey string) ([]Webhook, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/webhooks"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []Webhook
+	err = json.Unmarshal(body, &webhooks)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return webhooks, nil
+}
+
+func (s *Service) GetWebhook(projectIdOrKey string, webhookId int) (Webhook, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/webhooks/" + strconv.Itoa(webhookId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var webhook Webhook
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return webhook, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return webhook, err
+	}
+
+	err = json.Unmarshal(body, &webhook)
+	if err != nil {
+		return webhook, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) AddWebhook(projectIdOrKey string, params WebhookParams) (Webhook, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/webhooks"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := webhookRequestParams(params)
+
+	var webhook Webhook
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return webhook, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return webhook, err
+	}
+
+	err = json.Unmarshal(body, &webhook)
+	if err != nil {
+		return webhook, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) UpdateWebhook(projectIdOrKey string, webhookId int, params WebhookParams) (Webhook, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/webhooks/" + strconv.Itoa(webhookId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := webhookRequestParams(params)
+
+	var webhook Webhook
+
+	req, err := http.NewRequest(http.MethodPatch, requestUrl+"?"+urlParams.Encode(), strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return webhook, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return webhook, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return webhook, err
+	}
+
+	err = json.Unmarshal(body, &webhook)
+	if err != nil {
+		return webhook, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) DeleteWebhook(projectIdOrKey string, webhookId int) (Webhook, error) {
+	requestUrl := s.BaseUrl + "/api/v2/projects/" + projectIdOrKey + "/webhooks/" + strconv.Itoa(webhookId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	var webhook Webhook
+
+	req, err := http.NewRequest(http.MethodDelete, requestUrl+"?"+urlParams.Encode(), nil)
+	if err != nil {
+		return webhook, err
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return webhook, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return webhook, err
+	}
+
+	err = json.Unmarshal(body, &webhook)
+	if err != nil {
+		return webhook, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return webhook, nil
+}
+
+// EnsureWebhook makes sure the project has a webhook for params.HookUrl matching params,
+// adding or updating it only when needed.
+func (s *Service) EnsureWebhook(projectIdOrKey string, params WebhookParams) (Webhook, error) {
+	webhooks, err := s.GetWebhookList(projectIdOrKey)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.HookURL != params.HookUrl {
+			continue
+		}
+		if webhook.matches(params) {
+			return webhook, nil
+		}
+		return s.UpdateWebhook(projectIdOrKey, webhook.ID, params)
+	}
+
+	return s.AddWebhook(projectIdOrKey, params)
+}
+
+func (w Webhook) matches(params WebhookParams) bool {
+	if w.Name != params.Name || w.Description != params.Description || w.AllEvent != params.AllEvent {
+		return false
+	}
+	if params.AllEvent {
+		return true
+	}
+	if len(w.ActivityTypeIds) != len(params.ActivityTypeIds) {
+		return false
+	}
+
+	current := append([]ActivityType(nil), w.ActivityTypeIds...)
+	wanted := append([]ActivityType(nil), params.ActivityTypeIds...)
+	sort.Slice(current, func(i, j int) bool { return current[i] < current[j] })
+	sort.Slice(wanted, func(i, j int) bool { return wanted[i] < wanted[j] })
+	for i := range current {
+		if current[i] != wanted[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func webhookRequestParams(params WebhookParams) url.Values {
+	requestParams := url.Values{}
+	requestParams.Add("name", params.Name)
+	requestParams.Add("description", params.Description)
+	requestParams.Add("hookUrl", params.HookUrl)
+	requestParams.Add("allEvent", strconv.FormatBool(params.AllEvent))
+	if !params.AllEvent {
+		for _, activityTypeId := range params.ActivityTypeIds {
+			requestParams.Add("activityTypeIds[]", strconv.Itoa(int(activityTypeId)))
+		}
+	}
+	return requestParams
+}
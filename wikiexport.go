@@ -0,0 +1,414 @@
+package backlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type WikiExportOption struct {
+	ProjectId   int
+	Dir         string
+	Incremental bool // skip pages not updated since the last export
+	Attachments bool
+	Prune       bool // delete the files listed in WikiExportResult.Stale
+}
+
+type WikiExportResult struct {
+	Exported []string
+	Skipped  []string
+	Stale    []string // files in Dir, relative to it, of this project's pages that were deleted or renamed
+}
+
+type WikiFrontMatter struct {
+	ID          int
+	ProjectID   int
+	Name        string
+	Tags        []string
+	CreatedUser string
+	Created     time.Time
+	UpdatedUser string
+	Updated     time.Time
+}
+
+const wikiFileExt = ".md"
+
+func (s *Service) ExportWiki(option WikiExportOption) (WikiExportResult, error) {
+	var result WikiExportResult
+
+	if option.Dir == "" {
+		return result, errors.New("Dir not found")
+	}
+
+	wikiListItems, err := s.GetWikiPageList(GetWikiPageListQuery{ProjectIdOrKey: option.ProjectId})
+	if err != nil {
+		return result, err
+	}
+
+	current := map[string]bool{}
+	for _, item := range wikiListItems {
+		path := filepath.Join(option.Dir, WikiFilePath(item.Name))
+		current[path] = true
+
+		if option.Incremental {
+			frontMatter, _, err := ReadWikiFile(path)
+			if err == nil && frontMatter.ID == item.ID && !item.Updated.After(frontMatter.Updated) {
+				result.Skipped = append(result.Skipped, item.Name)
+				continue
+			}
+		}
+
+		wiki, err := s.GetWikiPage(item.ID)
+		if err != nil {
+			return result, err
+		}
+
+		err = WriteWikiFile(path, NewWikiFrontMatter(wiki), wiki.Content)
+		if err != nil {
+			return result, err
+		}
+
+		if option.Attachments && len(wiki.Attachments) > 0 {
+			err = s.exportWikiAttachments(wiki, wikiAttachmentDir(path))
+			if err != nil {
+				return result, err
+			}
+		}
+
+		result.Exported = append(result.Exported, item.Name)
+	}
+
+	stale, err := staleWikiFiles(option.Dir, option.ProjectId, current)
+	if err != nil {
+		return result, err
+	}
+	for _, path := range stale {
+		if option.Prune {
+			err = os.Remove(path)
+			if err != nil {
+				return result, err
+			}
+			err = os.RemoveAll(wikiAttachmentDir(path))
+			if err != nil {
+				return result, err
+			}
+		}
+
+		rel, err := filepath.Rel(option.Dir, path)
+		if err != nil {
+			return result, err
+		}
+		result.Stale = append(result.Stale, rel)
+	}
+
+	return result, nil
+}
+
+// staleWikiFiles returns the files in dir exported from projectId that don't belong to a current page.
+// Files from other projects, and files without a project ID in their front matter, are left alone.
+func staleWikiFiles(dir string, projectId int, current map[string]bool) ([]string, error) {
+	var stale []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if path == dir && os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(info.Name(), ".") || strings.HasSuffix(info.Name(), ".attachments")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != wikiFileExt || current[path] {
+			return nil
+		}
+
+		frontMatter, _, err := ReadWikiFile(path)
+		if err == nil && frontMatter.ID != 0 && frontMatter.ProjectID == projectId {
+			stale = append(stale, path)
+		}
+		return nil
+	})
+
+	return stale, err
+}
+
+func (s *Service) exportWikiAttachments(wiki DetailWiki, dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range wiki.Attachments {
+		err = s.exportWikiAttachment(wiki.ID, attachment, dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) exportWikiAttachment(wikiId int, attachment Attachment, dir string) error {
+	body, err := s.GetWikiPageAttachment(wikiId, attachment.ID)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	file, err := os.Create(filepath.Join(dir, sanitizeFileName(attachment.Name)))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, body)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+func NewWikiFrontMatter(wiki DetailWiki) WikiFrontMatter {
+	frontMatter := WikiFrontMatter{
+		ID:          wiki.ID,
+		ProjectID:   wiki.ProjectID,
+		Name:        wiki.Name,
+		CreatedUser: wiki.CreatedUser.UserID,
+		Created:     wiki.Created,
+		UpdatedUser: wiki.UpdatedUser.UserID,
+		Updated:     wiki.Updated,
+	}
+	for _, tag := range wiki.Tags {
+		frontMatter.Tags = append(frontMatter.Tags, tag.Name)
+	}
+	return frontMatter
+}
+
+// WikiFilePath converts a wiki page name into a relative file path.
+func WikiFilePath(name string) string {
+	segments := splitWikiPath(name)
+	for i, segment := range segments {
+		segments[i] = sanitizeFileName(segment)
+	}
+	return filepath.Join(segments...) + wikiFileExt
+}
+
+// WikiPageName is the reverse of WikiFilePath.
+func WikiPageName(path string) string {
+	path = strings.TrimSuffix(filepath.ToSlash(path), wikiFileExt)
+	segments := splitWikiPath(path)
+	for i, segment := range segments {
+		segments[i] = unsanitizeFileName(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func wikiAttachmentDir(path string) string {
+	return strings.TrimSuffix(path, wikiFileExt) + ".attachments"
+}
+
+var fileNameReplacer = strings.NewReplacer("%", "%25", "/", "%2F", "\\", "%5C")
+var fileNameUnreplacer = strings.NewReplacer("%2F", "/", "%5C", "\\", "%25", "%")
+
+func sanitizeFileName(name string) string {
+	switch name {
+	case ".":
+		return "%2E"
+	case "..":
+		return "%2E%2E"
+	}
+	return fileNameReplacer.Replace(name)
+}
+
+func unsanitizeFileName(name string) string {
+	switch name {
+	case "%2E":
+		return "."
+	case "%2E%2E":
+		return ".."
+	}
+	return fileNameUnreplacer.Replace(name)
+}
+
+func WriteWikiFile(path string, frontMatter WikiFrontMatter, content string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	if frontMatter.ID != 0 {
+		buf.WriteString("id: " + strconv.Itoa(frontMatter.ID) + "\n")
+	}
+	if frontMatter.ProjectID != 0 {
+		buf.WriteString("projectId: " + strconv.Itoa(frontMatter.ProjectID) + "\n")
+	}
+	writeFrontMatterValue(&buf, "name", frontMatter.Name)
+	if frontMatter.Tags != nil {
+		writeFrontMatterValue(&buf, "tags", frontMatter.Tags)
+	}
+	if frontMatter.CreatedUser != "" {
+		writeFrontMatterValue(&buf, "createdUser", frontMatter.CreatedUser)
+	}
+	if !frontMatter.Created.IsZero() {
+		buf.WriteString("created: " + frontMatter.Created.Format(time.RFC3339) + "\n")
+	}
+	if frontMatter.UpdatedUser != "" {
+		writeFrontMatterValue(&buf, "updatedUser", frontMatter.UpdatedUser)
+	}
+	if !frontMatter.Updated.IsZero() {
+		buf.WriteString("updated: " + frontMatter.Updated.Format(time.RFC3339) + "\n")
+	}
+	buf.WriteString("---\n")
+	buf.WriteString(content)
+
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// JSON strings and arrays are valid YAML flow scalars, so they are used to avoid quoting issues.
+func writeFrontMatterValue(buf *bytes.Buffer, key string, value interface{}) {
+	b, _ := json.Marshal(value)
+	buf.WriteString(key + ": ")
+	buf.Write(b)
+	buf.WriteString("\n")
+}
+
+// ReadWikiFile reads a Markdown file and splits off its front matter. Files without front matter
+// return a zero WikiFrontMatter and the whole file as content.
+func ReadWikiFile(path string) (WikiFrontMatter, string, error) {
+	var frontMatter WikiFrontMatter
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return frontMatter, "", err
+	}
+
+	text := strings.Replace(string(b), "\r\n", "\n", -1)
+	lines := strings.SplitAfter(text, "\n")
+	if lines[0] != "---\n" {
+		return frontMatter, text, nil
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSuffix(lines[i], "\n") == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return frontMatter, text, nil
+	}
+	header := strings.Join(lines[1:end], "")
+	content := strings.Join(lines[end+1:], "")
+
+	err = parseFrontMatter(header, &frontMatter)
+	if err != nil {
+		return frontMatter, content, errors.New(path + ": " + err.Error())
+	}
+
+	return frontMatter, content, nil
+}
+
+// parseFrontMatter reads the unindented keys it knows. Indented lines, such as nested maps and block
+// scalars, belong to the key above them and are skipped unless that key is tags.
+func parseFrontMatter(header string, frontMatter *WikiFrontMatter) error {
+	var key string
+	scanner := bufio.NewScanner(strings.NewReader(header))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "- ") || line == "-" {
+			if key == "tags" && strings.HasPrefix(trimmed, "- ") {
+				frontMatter.Tags = append(frontMatter.Tags, parseFrontMatterString(strings.TrimSpace(trimmed[2:])))
+			}
+			continue
+		}
+
+		i := strings.Index(line, ":")
+		if i < 0 {
+			return errors.New("invalid front matter line: " + line)
+		}
+		key = strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+
+		var err error
+		switch key {
+		case "id":
+			frontMatter.ID, err = strconv.Atoi(value)
+		case "projectId":
+			frontMatter.ProjectID, err = strconv.Atoi(value)
+		case "name":
+			frontMatter.Name = parseFrontMatterString(value)
+		case "tags":
+			if value == "" {
+				frontMatter.Tags = []string{}
+			} else {
+				frontMatter.Tags = parseFrontMatterList(value)
+			}
+		case "createdUser":
+			frontMatter.CreatedUser = parseFrontMatterString(value)
+		case "created":
+			frontMatter.Created, err = time.Parse(time.RFC3339, parseFrontMatterString(value))
+		case "updatedUser":
+			frontMatter.UpdatedUser = parseFrontMatterString(value)
+		case "updated":
+			frontMatter.Updated, err = time.Parse(time.RFC3339, parseFrontMatterString(value))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func parseFrontMatterString(value string) string {
+	if strings.HasPrefix(value, "\"") {
+		var s string
+		if json.Unmarshal([]byte(value), &s) == nil {
+			return s
+		}
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return strings.Replace(value[1:len(value)-1], "''", "'", -1)
+	}
+	return value
+}
+
+func parseFrontMatterList(value string) []string {
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return []string{parseFrontMatterString(value)}
+	}
+
+	var list []string
+	if json.Unmarshal([]byte(value), &list) == nil {
+		return list
+	}
+
+	list = []string{}
+	for _, item := range strings.Split(value[1:len(value)-1], ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, parseFrontMatterString(item))
+		}
+	}
+	return list
+}
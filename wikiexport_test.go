@@ -0,0 +1,123 @@
+package backlog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   WikiFrontMatter
+	}{
+		{
+			name:   "exported",
+			header: "id: 12\nprojectId: 3\nname: \"docs/setup\"\ntags: [\"a\",\"b\"]\ncreatedUser: \"bob\"\ncreated: 2020-01-02T03:04:05Z\n",
+			want:   WikiFrontMatter{ID: 12, ProjectID: 3, Name: "docs/setup", Tags: []string{"a", "b"}, CreatedUser: "bob", Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		},
+		{
+			name:   "nested map",
+			header: "name: setup\nauthor:\n  name: bob\n  email: bob@example.com\n",
+			want:   WikiFrontMatter{Name: "setup"},
+		},
+		{
+			name:   "block scalar",
+			header: "description: |\n  First line\n  name: not a key\n\n  last line\nname: setup\n",
+			want:   WikiFrontMatter{Name: "setup"},
+		},
+		{
+			name:   "folded block scalar",
+			header: "summary: >-\n  folded\n  text\n",
+			want:   WikiFrontMatter{},
+		},
+		{
+			name:   "indented list",
+			header: "tags:\n  - a\n  - 'b c'\nname: x\n",
+			want:   WikiFrontMatter{Name: "x", Tags: []string{"a", "b c"}},
+		},
+		{
+			name:   "unindented list",
+			header: "tags:\n- a\n- \"b\"\n",
+			want:   WikiFrontMatter{Tags: []string{"a", "b"}},
+		},
+		{
+			name:   "list under another key",
+			header: "authors:\n  - bob\n- alice\ntags: [a]\n",
+			want:   WikiFrontMatter{Tags: []string{"a"}},
+		},
+		{
+			name:   "title is not the page name",
+			header: "title: Installing\n",
+			want:   WikiFrontMatter{},
+		},
+		{
+			name:   "comments",
+			header: "# generated\nname: x\n",
+			want:   WikiFrontMatter{Name: "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got WikiFrontMatter
+			err := parseFrontMatter(tt.header, &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFrontMatter() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFrontMatterInvalid(t *testing.T) {
+	var got WikiFrontMatter
+	err := parseFrontMatter("name: x\nnot a key\n", &got)
+	if err == nil {
+		t.Error("expected an error for an unindented line without a key")
+	}
+}
+
+func TestStaleWikiFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wiki")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]WikiFrontMatter{
+		"current.md":         {ID: 1, ProjectID: 10, Name: "current"},
+		"renamed/old.md":     {ID: 2, ProjectID: 10, Name: "renamed/old"},
+		"other/page.md":      {ID: 3, ProjectID: 20, Name: "other/page"},
+		"legacy.md":          {ID: 4, Name: "legacy"},
+		"handwritten.md":     {Name: "handwritten"},
+		".hidden/page.md":    {ID: 5, ProjectID: 10, Name: "hidden"},
+		"x.attachments/a.md": {ID: 6, ProjectID: 10, Name: "a"},
+	}
+	for name, frontMatter := range files {
+		err = WriteWikiFile(filepath.Join(dir, name), frontMatter, "content")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stale, err := staleWikiFiles(dir, 10, map[string]bool{filepath.Join(dir, "current.md"): true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "renamed", "old.md")}
+	if !reflect.DeepEqual(stale, want) {
+		t.Errorf("staleWikiFiles() = %v, want %v", stale, want)
+	}
+
+	stale, err = staleWikiFiles(filepath.Join(dir, "missing"), 10, nil)
+	if err != nil || len(stale) != 0 {
+		t.Errorf("staleWikiFiles(missing) = %v, %v", stale, err)
+	}
+}
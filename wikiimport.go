@@ -0,0 +1,318 @@
+package backlog
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type WikiImportOption struct {
+	ProjectId  int
+	Dir        string
+	DryRun     bool // report what would change without calling the API
+	MailNotify bool
+}
+
+const (
+	WikiImportCreate = "create"
+	WikiImportUpdate = "update"
+	WikiImportSkip   = "skip"
+)
+
+type WikiImportEntry struct {
+	Path        string
+	Name        string
+	Action      string // create, update, skip
+	Attachments []string
+	Wiki        DetailWiki // empty on dry run
+}
+
+type WikiImportResult struct {
+	Entries []WikiImportEntry
+}
+
+type wikiImportFile struct {
+	path        string
+	frontMatter WikiFrontMatter
+	content     string
+	attachments []string
+}
+
+var markdownLinkRegexp = regexp.MustCompile(`!?\[[^\]]*\]\(<?([^)\s>]+)>?(?:\s+"[^"]*")?\)`)
+
+func (s *Service) ImportWiki(option WikiImportOption) (WikiImportResult, error) {
+	var result WikiImportResult
+
+	if option.Dir == "" {
+		return result, errors.New("Dir not found")
+	}
+
+	files, err := readWikiImportFiles(option.Dir)
+	if err != nil {
+		return result, err
+	}
+
+	wikiListItems, err := s.GetWikiPageList(GetWikiPageListQuery{ProjectIdOrKey: option.ProjectId})
+	if err != nil {
+		return result, err
+	}
+	existing := map[string]WikiListItem{}
+	for _, item := range wikiListItems {
+		existing[item.Name] = item
+	}
+
+	for _, file := range files {
+		entry := WikiImportEntry{
+			Path: file.path,
+			Name: file.frontMatter.Name,
+		}
+		_, err = wikiTagPrefix(nil, file.frontMatter.Tags)
+		if err != nil {
+			return result, errors.New(file.path + ": " + err.Error())
+		}
+
+		item, ok := existing[entry.Name]
+		if !ok {
+			entry.Action = WikiImportCreate
+			for _, attachment := range file.attachments {
+				entry.Attachments = append(entry.Attachments, filepath.Base(attachment))
+			}
+			if !option.DryRun {
+				entry.Wiki, err = s.importWikiPage(option, file, nil)
+				if err != nil {
+					return result, err
+				}
+			}
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+
+		wiki, err := s.GetWikiPage(item.ID)
+		if err != nil {
+			return result, err
+		}
+
+		attached := map[string]bool{}
+		for _, attachment := range wiki.Attachments {
+			attached[attachment.Name] = true
+		}
+		var attachments []string
+		for _, attachment := range file.attachments {
+			if !attached[filepath.Base(attachment)] {
+				attachments = append(attachments, attachment)
+				entry.Attachments = append(entry.Attachments, filepath.Base(attachment))
+			}
+		}
+
+		prefix, err := wikiTagPrefix(wiki.Tags, file.frontMatter.Tags)
+		if err != nil {
+			return result, err
+		}
+
+		if wiki.Content == file.content && len(attachments) == 0 && prefix == "" {
+			entry.Action = WikiImportSkip
+			entry.Wiki = wiki
+			result.Entries = append(result.Entries, entry)
+			continue
+		}
+
+		entry.Action = WikiImportUpdate
+		if !option.DryRun {
+			file.attachments = attachments
+			entry.Wiki, err = s.importWikiPage(option, file, &wiki)
+			if err != nil {
+				return result, err
+			}
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return result, nil
+}
+
+func (s *Service) importWikiPage(option WikiImportOption, file wikiImportFile, current *DetailWiki) (DetailWiki, error) {
+	var tags []Tag
+	if current != nil {
+		tags = current.Tags
+	}
+	prefix, err := wikiTagPrefix(tags, file.frontMatter.Tags)
+	if err != nil {
+		return DetailWiki{}, err
+	}
+
+	wiki := Wiki{
+		ProjectId:  option.ProjectId,
+		Name:       prefix + file.frontMatter.Name,
+		Content:    file.content,
+		MailNotify: option.MailNotify,
+	}
+
+	var detailWiki DetailWiki
+	if current == nil {
+		detailWiki, err = s.AddWikiPage(wiki)
+	} else if current.Content != file.content || prefix != "" {
+		detailWiki, err = s.UpdateWikiPage(current.ID, wiki)
+	} else {
+		detailWiki = *current
+	}
+	if err != nil {
+		return detailWiki, err
+	}
+
+	if len(file.attachments) == 0 {
+		return detailWiki, nil
+	}
+
+	var attachmentIds []int
+	for _, path := range file.attachments {
+		attachment, err := s.uploadAttachmentFile(path)
+		if err != nil {
+			return detailWiki, err
+		}
+		attachmentIds = append(attachmentIds, attachment.ID)
+	}
+
+	attachments, err := s.AttachFileToWiki(detailWiki.ID, attachmentIds)
+	if err != nil {
+		return detailWiki, err
+	}
+	detailWiki.Attachments = append(detailWiki.Attachments, attachments...)
+
+	return detailWiki, nil
+}
+
+func (s *Service) uploadAttachmentFile(path string) (Attachment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Attachment{}, err
+	}
+	defer file.Close()
+
+	return s.PostAttachmentFile(filepath.Base(path), file)
+}
+
+func readWikiImportFiles(dir string) ([]wikiImportFile, error) {
+	var files []wikiImportFile
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(info.Name(), ".") || strings.HasSuffix(info.Name(), ".attachments")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != wikiFileExt {
+			return nil
+		}
+
+		frontMatter, content, err := ReadWikiFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if frontMatter.Name == "" {
+			frontMatter.Name = WikiPageName(rel)
+		}
+
+		attachments, err := wikiImportAttachments(dir, path, content)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, wikiImportFile{
+			path:        rel,
+			frontMatter: frontMatter,
+			content:     content,
+			attachments: attachments,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// parents are created before their children
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].frontMatter.Name < files[j].frontMatter.Name
+	})
+
+	return files, nil
+}
+
+// wikiImportAttachments collects the files in the page's attachment directory written by ExportWiki
+// and local files referenced by relative Markdown links. Links that lead outside root are ignored.
+func wikiImportAttachments(root string, path string, content string) ([]string, error) {
+	var attachments []string
+	seen := map[string]bool{}
+	add := func(attachment string) {
+		if !seen[filepath.Base(attachment)] {
+			seen[filepath.Base(attachment)] = true
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	dir := wikiAttachmentDir(path)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, info := range infos {
+		if !info.IsDir() {
+			add(filepath.Join(dir, info.Name()))
+		}
+	}
+
+	for _, match := range markdownLinkRegexp.FindAllStringSubmatch(content, -1) {
+		link := match[1]
+		if strings.Contains(link, "://") || strings.HasPrefix(link, "#") || strings.HasPrefix(link, "/") || strings.HasPrefix(link, "mailto:") {
+			continue
+		}
+		if i := strings.IndexAny(link, "?#"); i >= 0 {
+			link = link[:i]
+		}
+		if filepath.Ext(link) == wikiFileExt {
+			continue
+		}
+
+		attachment := filepath.Join(filepath.Dir(path), filepath.FromSlash(link))
+		if !isUnderDir(root, attachment) {
+			continue
+		}
+		info, err := os.Stat(attachment)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		add(attachment)
+	}
+
+	return attachments, nil
+}
+
+// isUnderDir reports whether path, after resolving symbolic links, is inside dir.
+func isUnderDir(dir string, path string) bool {
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false
+	}
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
@@ -6,6 +6,7 @@ import (
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -68,18 +69,18 @@ type WikiListItem struct {
 }
 
 type DetailWiki struct {
-	ID          int           `json:"id"`
-	ProjectID   int           `json:"projectId"`
-	Name        string        `json:"name"`
-	Content     string        `json:"content"`
-	Tags        []Tag         `json:"tags"`
-	Attachments []Attachment  `json:"attachments"`
-	SharedFiles []SharedFile  `json:"sharedFiles"`
-	Stars       []interface{} `json:"stars"`
-	CreatedUser User          `json:"createdUser"`
-	Created     time.Time     `json:"created"`
-	UpdatedUser User          `json:"updatedUser"`
-	Updated     time.Time     `json:"updated"`
+	ID          int          `json:"id"`
+	ProjectID   int          `json:"projectId"`
+	Name        string       `json:"name"`
+	Content     string       `json:"content"`
+	Tags        []Tag        `json:"tags"`
+	Attachments []Attachment `json:"attachments"`
+	SharedFiles []SharedFile `json:"sharedFiles"`
+	Stars       []Star       `json:"stars"`
+	CreatedUser User         `json:"createdUser"`
+	Created     time.Time    `json:"created"`
+	UpdatedUser User         `json:"updatedUser"`
+	Updated     time.Time    `json:"updated"`
 }
 
 func (s *Service) GetWikiPageList(query GetWikiPageListQuery) ([]WikiListItem, error) {
@@ -300,9 +301,59 @@ func (s *Service) DeleteWikiPage(wikiId int) (DetailWiki, error) {
 	return wiki, nil
 }
 
+func (s *Service) GetWikiPageAttachment(wikiId int, attachmentId int) (io.ReadCloser, error) {
+	requestUrl := s.BaseUrl + "/api/v2/wikis/" + strconv.Itoa(wikiId) + "/attachments/" + strconv.Itoa(attachmentId)
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	res, err := s.client.Get(requestUrl + "?" + urlParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return res.Body, nil
+}
+
+func (s *Service) AttachFileToWiki(wikiId int, attachmentIds []int) ([]Attachment, error) {
+	requestUrl := s.BaseUrl + "/api/v2/wikis/" + strconv.Itoa(wikiId) + "/attachments"
+	urlParams := url.Values{}
+	urlParams.Add("apiKey", s.Config.ApiKey)
+
+	requestParams := url.Values{}
+	for _, attachmentId := range attachmentIds {
+		requestParams.Add("attachmentId[]", strconv.Itoa(attachmentId))
+	}
+
+	res, err := s.client.Post(requestUrl+"?"+urlParams.Encode(), "application/x-www-form-urlencoded", strings.NewReader(requestParams.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []Attachment
+	err = json.Unmarshal(body, &attachments)
+	if err != nil {
+		return nil, errors.New(*(*string)(unsafe.Pointer(&body)))
+	}
+
+	return attachments, nil
+}
+
 // func (s *Service) GetListOfWikiAttachments() (string, error) {}
-// func (s *Service) AttachFileToWiki() (string, error) {}
-// func (s *Service) GetWikiPageAttachment() (string, error) {}
 // func (s *Service) RemoveWikiAttachment() (string, error) {}
 // func (s *Service) GetListOfSharedFilesOnWiki() (string, error) {}
 // func (s *Service) LinkSharedFilesToWiki() (string, error) {}
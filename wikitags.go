@@ -0,0 +1,100 @@
+package backlog
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+type TagCount struct {
+	Tag   Tag
+	Count int
+}
+
+func (s *Service) GetWikiPageListByTag(query GetWikiPageListQuery, tagName string) ([]WikiListItem, error) {
+	wikiListItems, err := s.GetWikiPageList(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterWikiPagesByTag(wikiListItems, tagName), nil
+}
+
+func FilterWikiPagesByTag(items []WikiListItem, tagName string) []WikiListItem {
+	var filtered []WikiListItem
+	for _, item := range items {
+		if hasTag(item.Tags, tagName) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// CountWikiPageTags returns how many pages use each tag, most used first.
+func CountWikiPageTags(items []WikiListItem) []TagCount {
+	counts := map[int]*TagCount{}
+	for _, item := range items {
+		for _, tag := range item.Tags {
+			if counts[tag.ID] == nil {
+				counts[tag.ID] = &TagCount{Tag: tag}
+			}
+			counts[tag.ID].Count++
+		}
+	}
+
+	tagCounts := make([]TagCount, 0, len(counts))
+	for _, count := range counts {
+		tagCounts = append(tagCounts, *count)
+	}
+	sort.Slice(tagCounts, func(i, j int) bool {
+		if tagCounts[i].Count != tagCounts[j].Count {
+			return tagCounts[i].Count > tagCounts[j].Count
+		}
+		return tagCounts[i].Tag.Name < tagCounts[j].Tag.Name
+	})
+
+	return tagCounts
+}
+
+// AddWikiPageTags tags a page by renaming it with the "[tag]name" convention, which Backlog turns into tags.
+// The API has no way to detach a tag from a page, so tags can only be added.
+func (s *Service) AddWikiPageTags(wikiId int, tagNames []string) (DetailWiki, error) {
+	wiki, err := s.GetWikiPage(wikiId)
+	if err != nil {
+		return wiki, err
+	}
+
+	prefix, err := wikiTagPrefix(wiki.Tags, tagNames)
+	if err != nil || prefix == "" {
+		return wiki, err
+	}
+
+	return s.UpdateWikiPage(wikiId, Wiki{
+		ProjectId: wiki.ProjectID,
+		Name:      prefix + wiki.Name,
+		Content:   wiki.Content,
+	})
+}
+
+// wikiTagPrefix builds the "[tag]" markers for the tags in tagNames that are not in tags yet.
+func wikiTagPrefix(tags []Tag, tagNames []string) (string, error) {
+	var prefix string
+	for _, tagName := range tagNames {
+		if tagName == "" || strings.ContainsAny(tagName, "[]") {
+			return "", errors.New("invalid tag name: " + tagName)
+		}
+		if !hasTag(tags, tagName) {
+			prefix += "[" + tagName + "]"
+		}
+	}
+	return prefix, nil
+}
+
+func hasTag(tags []Tag, tagName string) bool {
+	for _, tag := range tags {
+		if tag.Name == tagName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package backlog
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+type WikiTree struct {
+	Root    *WikiTreeNode
+	Orphans []*WikiTreeNode // pages whose parent page does not exist
+}
+
+type WikiTreeNode struct {
+	Name     string        // last segment of the page name
+	Path     string        // full page name
+	Page     *WikiListItem // nil when no page exists at this path
+	Parent   *WikiTreeNode
+	Children []*WikiTreeNode
+}
+
+func NewWikiTree(items []WikiListItem) *WikiTree {
+	tree := &WikiTree{Root: &WikiTreeNode{}}
+
+	for i := range items {
+		segments := splitWikiPath(items[i].Name)
+		if len(segments) == 0 {
+			continue
+		}
+
+		node := tree.Root
+		for _, segment := range segments {
+			child := node.child(segment)
+			if child == nil {
+				child = &WikiTreeNode{
+					Name:   segment,
+					Path:   joinWikiPath(node.Path, segment),
+					Parent: node,
+				}
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+		if node.Page == nil {
+			node.Page = &items[i]
+		}
+	}
+
+	tree.Root.Walk(func(node *WikiTreeNode) {
+		if node.Page != nil && node.Parent != tree.Root && node.Parent.Page == nil {
+			tree.Orphans = append(tree.Orphans, node)
+		}
+	})
+	tree.SortByName()
+
+	return tree
+}
+
+func (t *WikiTree) Find(name string) *WikiTreeNode {
+	node := t.Root
+	for _, segment := range splitWikiPath(name) {
+		node = node.child(segment)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func (t *WikiTree) SortByName() {
+	t.sort(func(a, b *WikiTreeNode) bool {
+		return a.Name < b.Name
+	})
+}
+
+// SortByUpdated orders siblings by the most recently updated first. Nodes without a page are placed last.
+func (t *WikiTree) SortByUpdated() {
+	t.sort(func(a, b *WikiTreeNode) bool {
+		if a.Page == nil || b.Page == nil {
+			return a.Page != nil
+		}
+		return a.Page.Updated.After(b.Page.Updated)
+	})
+}
+
+func (t *WikiTree) sort(less func(a, b *WikiTreeNode) bool) {
+	t.Root.Walk(func(node *WikiTreeNode) {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return less(node.Children[i], node.Children[j])
+		})
+	})
+	sort.SliceStable(t.Orphans, func(i, j int) bool {
+		return t.Orphans[i].Path < t.Orphans[j].Path
+	})
+}
+
+// Walk calls fn for the node and all its descendants in depth-first order.
+func (n *WikiTreeNode) Walk(fn func(node *WikiTreeNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+func (n *WikiTreeNode) Pages() []WikiListItem {
+	var pages []WikiListItem
+	n.Walk(func(node *WikiTreeNode) {
+		if node.Page != nil {
+			pages = append(pages, *node.Page)
+		}
+	})
+	return pages
+}
+
+func (n *WikiTreeNode) child(name string) *WikiTreeNode {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+func splitWikiPath(name string) []string {
+	var segments []string
+	for _, segment := range strings.Split(name, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func joinWikiPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+func (s *Service) GetWikiTree(query GetWikiPageListQuery) (*WikiTree, error) {
+	wikiListItems, err := s.GetWikiPageList(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWikiTree(wikiListItems), nil
+}
+
+// RenameWikiSubtree renames the page at from and every page below it so that they live under to.
+func (s *Service) RenameWikiSubtree(projectId int, from string, to string) ([]DetailWiki, error) {
+	from = strings.Join(splitWikiPath(from), "/")
+	to = strings.Join(splitWikiPath(to), "/")
+	if from == "" || to == "" {
+		return nil, errors.New("wiki page name is empty")
+	}
+	if from == to {
+		return nil, nil
+	}
+	if strings.HasPrefix(to+"/", from+"/") {
+		return nil, errors.New("cannot move " + from + " into its own subtree")
+	}
+
+	tree, err := s.GetWikiTree(GetWikiPageListQuery{ProjectIdOrKey: projectId})
+	if err != nil {
+		return nil, err
+	}
+
+	node := tree.Find(from)
+	if node == nil {
+		return nil, errors.New("wiki page not found: " + from)
+	}
+
+	rename := func(name string) string {
+		return to + strings.TrimPrefix(strings.Join(splitWikiPath(name), "/"), from)
+	}
+
+	pages := node.Pages()
+	for _, page := range pages {
+		name := rename(page.Name)
+		if exists := tree.Find(name); exists != nil && exists.Page != nil {
+			return nil, errors.New("wiki page already exists: " + name)
+		}
+	}
+
+	var renamed []DetailWiki
+	for _, page := range pages {
+		detailWiki, err := s.GetWikiPage(page.ID)
+		if err != nil {
+			return renamed, err
+		}
+
+		wiki := Wiki{
+			ProjectId: projectId,
+			Name:      rename(page.Name),
+			Content:   detailWiki.Content,
+		}
+		detailWiki, err = s.UpdateWikiPage(page.ID, wiki)
+		if err != nil {
+			return renamed, err
+		}
+		renamed = append(renamed, detailWiki)
+	}
+
+	return renamed, nil
+}